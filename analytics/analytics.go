@@ -0,0 +1,188 @@
+// Package analytics computes spending and winnings over time from a ticket
+// history: totals per week, month or year with a running net result, streaks,
+// the biggest win and the return on spend. The stats command, the TUI Stats tab
+// and exports share it. It also checks spending against budget limits and
+// analyzes archived draws for the analyze command.
+package analytics
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// Period is the length of the buckets a history is broken down into
+type Period string
+
+const (
+	Week  Period = "week" // ISO weeks, starting on Monday
+	Month Period = "month"
+	Year  Period = "year"
+)
+
+// Periods lists every period in increasing length
+var Periods = []Period{Week, Month, Year}
+
+// ParsePeriod maps a user-supplied period name such as "month" or "monthly" to a Period
+func ParsePeriod(name string) (Period, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for _, p := range Periods {
+		if key == string(p) || key == string(p)+"ly" {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown period %q (expected week, month or year)", name)
+}
+
+// Bucket totals the tickets played in one period
+type Bucket struct {
+	Key           string       `json:"key"`   // e.g. "2026-W07", "2026-02" or "2026"
+	Start         time.Time    `json:"start"` // first day of the period, local midnight
+	Tickets       int          `json:"tickets"`
+	Won           int          `json:"won"`
+	Spent         models.Money `json:"spent"`
+	WonAmount     models.Money `json:"won_amount"`
+	Net           models.Money `json:"net"`            // WonAmount minus Spent
+	CumulativeNet models.Money `json:"cumulative_net"` // net of this and every earlier period
+}
+
+// Win is a single winning ticket
+type Win struct {
+	TicketID string       `json:"ticket_id"`
+	Game     models.Game  `json:"game"`
+	DrawDate string       `json:"draw_date"`
+	Prize    models.Money `json:"prize"`
+}
+
+// Report is the time-series view of a ticket history
+type Report struct {
+	Period              Period       `json:"period"`
+	Buckets             []Bucket     `json:"buckets"`
+	Draws               int          `json:"draws"`              // distinct draws played (game and draw date)
+	AvgSpendPerDraw     models.Money `json:"avg_spend_per_draw"` // rounded down to the ban
+	ReturnOnSpend       float64      `json:"return_on_spend"`    // winnings as a percentage of spending
+	BiggestWin          *Win         `json:"biggest_win,omitempty"`
+	LongestLosingStreak int          `json:"longest_losing_streak"` // most lost tickets in a row, by draw date
+	CurrentLosingStreak int          `json:"current_losing_streak"` // lost tickets since the last win
+}
+
+// Compute builds the report of a ticket history, bucketed by period
+func Compute(tickets []models.Ticket, period Period) Report {
+	r := Report{Period: period, Buckets: Breakdown(tickets, period)}
+
+	var spent, won models.Money
+	draws := make(map[string]bool)
+	for _, t := range tickets {
+		spent = spent.Add(t.PriceAmount)
+		if t.Status == models.StatusWon {
+			won = won.Add(t.PrizeAmount)
+			if t.PrizeAmount.Amount > 0 && (r.BiggestWin == nil || t.PrizeAmount.Amount > r.BiggestWin.Prize.Amount) {
+				r.BiggestWin = &Win{TicketID: t.TicketID, Game: t.Game, DrawDate: t.DrawDate, Prize: t.PrizeAmount}
+			}
+		}
+		draws[string(t.Game)+"|"+t.DrawDate] = true
+	}
+
+	r.Draws = len(draws)
+	if r.Draws > 0 {
+		r.AvgSpendPerDraw = models.Money{Amount: spent.Amount / int64(r.Draws), Currency: spent.Currency}
+	}
+	if spent.Amount > 0 {
+		r.ReturnOnSpend = float64(won.Amount) / float64(spent.Amount) * 100
+	}
+	r.LongestLosingStreak, r.CurrentLosingStreak = losingStreaks(tickets)
+
+	return r
+}
+
+// Breakdown totals tickets per period they were played in (the draw day if the
+// time played is unknown), oldest first. Only periods with tickets are listed;
+// tickets without either date are left out.
+func Breakdown(tickets []models.Ticket, period Period) []Bucket {
+	byKey := make(map[string]*Bucket)
+	for _, t := range tickets {
+		at := PlayedAt(t)
+		if at.IsZero() {
+			continue
+		}
+		key, start := bucketOf(at, period)
+		b, ok := byKey[key]
+		if !ok {
+			b = &Bucket{Key: key, Start: start, Spent: models.RON(0, 0), WonAmount: models.RON(0, 0)}
+			byKey[key] = b
+		}
+
+		b.Tickets++
+		b.Spent = b.Spent.Add(t.PriceAmount)
+		if t.Status == models.StatusWon {
+			b.Won++
+			b.WonAmount = b.WonAmount.Add(t.PrizeAmount)
+		}
+	}
+
+	buckets := make([]Bucket, 0, len(byKey))
+	for _, b := range byKey {
+		b.Net = b.WonAmount.Sub(b.Spent)
+		buckets = append(buckets, *b)
+	}
+	slices.SortFunc(buckets, func(a, b Bucket) int { return a.Start.Compare(b.Start) })
+
+	cumulative := models.RON(0, 0)
+	for i := range buckets {
+		cumulative = cumulative.Add(buckets[i].Net)
+		buckets[i].CumulativeNet = cumulative
+	}
+	return buckets
+}
+
+// bucketOf returns the key and first day of the period containing t
+func bucketOf(t time.Time, period Period) (string, time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case Day:
+		return day.Format("2006-01-02"), day
+	case Week:
+		monday := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+		year, week := day.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week), monday
+	case Year:
+		return fmt.Sprintf("%d", t.Year()), time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return t.Format("2006-01"), time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// PlayedAt returns when a ticket was played, or its draw day if that is unknown
+func PlayedAt(t models.Ticket) time.Time {
+	if !t.PlayedTime.IsZero() {
+		return t.PlayedTime
+	}
+	return t.DrawTime
+}
+
+// losingStreaks returns the most lost tickets in a row and the lost tickets since
+// the last win, in draw order. Pending tickets don't break a streak.
+func losingStreaks(tickets []models.Ticket) (longest, current int) {
+	// Site order is newest first; reversed, tickets of the same draw stay in play order
+	ordered := slices.Clone(tickets)
+	slices.Reverse(ordered)
+	slices.SortStableFunc(ordered, func(a, b models.Ticket) int {
+		if c := a.DrawTime.Compare(b.DrawTime); c != 0 {
+			return c
+		}
+		return a.PlayedTime.Compare(b.PlayedTime)
+	})
+	for _, t := range ordered {
+		switch t.Status {
+		case models.StatusLost:
+			current++
+			longest = max(longest, current)
+		case models.StatusWon:
+			current = 0
+		}
+	}
+	return longest, current
+}
@@ -0,0 +1,115 @@
+package analytics
+
+import (
+	"testing"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// ticket builds a parsed ticket; tickets are listed newest first, as on the site
+func ticket(id, game, drawDate string, status models.TicketStatus, price, prize string) models.Ticket {
+	t := models.Ticket{TicketID: id, Game: models.Game(game), DrawDate: drawDate, Status: status, Price: price, Prize: prize}
+	t.Parse()
+	return t
+}
+
+func testTickets() []models.Ticket {
+	return []models.Ticket{
+		ticket("7", "Joker", "02.03.2026", models.StatusPending, "10,00 RON", ""),
+		ticket("6", "Loto 6/49", "26.02.2026", models.StatusLost, "10,00 RON", ""),
+		ticket("5", "Loto 6/49", "22.02.2026", models.StatusLost, "10,00 RON", ""),
+		ticket("4", "Loto 6/49", "15.02.2026", models.StatusWon, "10,00 RON", "30,00 RON"),
+		ticket("3", "Joker", "15.02.2026", models.StatusLost, "5,00 RON", ""),
+		ticket("2", "Loto 6/49", "15.02.2026", models.StatusLost, "10,00 RON", ""),
+		ticket("1", "Loto 6/49", "29.12.2025", models.StatusWon, "10,00 RON", "100,00 RON"),
+	}
+}
+
+func TestBreakdownMonths(t *testing.T) {
+	buckets := Breakdown(testTickets(), Month)
+
+	want := []struct {
+		key                string
+		tickets, won       int
+		spent, net, cumNet models.Money
+	}{
+		{"2025-12", 1, 1, models.RON(10, 0), models.RON(90, 0), models.RON(90, 0)},
+		{"2026-02", 5, 1, models.RON(45, 0), models.RON(-15, 0), models.RON(75, 0)},
+		{"2026-03", 1, 0, models.RON(10, 0), models.RON(-10, 0), models.RON(65, 0)},
+	}
+	if len(buckets) != len(want) {
+		t.Fatalf("got %d buckets, want %d: %+v", len(buckets), len(want), buckets)
+	}
+	for i, w := range want {
+		b := buckets[i]
+		if b.Key != w.key || b.Tickets != w.tickets || b.Won != w.won || b.Spent != w.spent || b.Net != w.net || b.CumulativeNet != w.cumNet {
+			t.Errorf("bucket %d = %+v, want %+v", i, b, w)
+		}
+	}
+}
+
+func TestBreakdownWeeksAndYears(t *testing.T) {
+	weeks := Breakdown(testTickets(), Week)
+	keys := make([]string, len(weeks))
+	for i, b := range weeks {
+		keys[i] = b.Key
+	}
+	// 29.12.2025 is in ISO week 1 of 2026; 15.02.2026 is a Sunday, so it closes week 7
+	wantKeys := []string{"2026-W01", "2026-W07", "2026-W08", "2026-W09", "2026-W10"}
+	if len(keys) != len(wantKeys) {
+		t.Fatalf("week keys = %v, want %v", keys, wantKeys)
+	}
+	for i := range keys {
+		if keys[i] != wantKeys[i] {
+			t.Errorf("week keys = %v, want %v", keys, wantKeys)
+			break
+		}
+	}
+	if got := weeks[1].Start.Format("02.01.2006"); got != "09.02.2026" {
+		t.Errorf("week 7 starts %s, want 09.02.2026 (Monday)", got)
+	}
+
+	years := Breakdown(testTickets(), Year)
+	if len(years) != 2 || years[0].Key != "2025" || years[1].Key != "2026" || years[1].CumulativeNet != models.RON(65, 0) {
+		t.Errorf("years = %+v", years)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	r := Compute(testTickets(), Month)
+
+	if r.Draws != 6 {
+		t.Errorf("Draws = %d, want 6 (two games on 15.02.2026)", r.Draws)
+	}
+	if r.AvgSpendPerDraw != models.RON(10, 83) {
+		t.Errorf("AvgSpendPerDraw = %v, want 10,83 RON", r.AvgSpendPerDraw)
+	}
+	if r.ReturnOnSpend < 199.99 || r.ReturnOnSpend > 200.01 {
+		t.Errorf("ReturnOnSpend = %.2f, want 200", r.ReturnOnSpend)
+	}
+	if r.BiggestWin == nil || r.BiggestWin.TicketID != "1" || r.BiggestWin.Prize != models.RON(100, 0) {
+		t.Errorf("BiggestWin = %+v, want ticket 1 with 100 RON", r.BiggestWin)
+	}
+	// Lost 2 and 3 before winning with 4 on the same draw day, then lost 5 and 6
+	if r.LongestLosingStreak != 2 || r.CurrentLosingStreak != 2 {
+		t.Errorf("streaks = %d longest, %d current; want 2, 2", r.LongestLosingStreak, r.CurrentLosingStreak)
+	}
+}
+
+func TestComputeEmpty(t *testing.T) {
+	r := Compute(nil, Year)
+	if len(r.Buckets) != 0 || r.Draws != 0 || r.BiggestWin != nil || r.ReturnOnSpend != 0 {
+		t.Errorf("Compute(nil) = %+v", r)
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	for name, want := range map[string]Period{"week": Week, "Monthly": Month, " year ": Year} {
+		if got, err := ParsePeriod(name); err != nil || got != want {
+			t.Errorf("ParsePeriod(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParsePeriod("day"); err == nil {
+		t.Error("ParsePeriod accepted day")
+	}
+}
@@ -0,0 +1,89 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// Day is the period of a daily budget limit. It is not in Periods, as breaking
+// a history down per day is rarely useful.
+const Day Period = "day"
+
+// Adjective names a period as the budget limits in the config do: daily, weekly, monthly or yearly
+func (p Period) Adjective() string {
+	if p == Day {
+		return "daily"
+	}
+	return string(p) + "ly"
+}
+
+// Limits are spending limits per day, ISO week and calendar month. A zero limit is not checked.
+type Limits struct {
+	Daily   models.Money
+	Weekly  models.Money
+	Monthly models.Money
+}
+
+// IsZero reports whether no limit is set
+func (l Limits) IsZero() bool {
+	return l.Daily.IsZero() && l.Weekly.IsZero() && l.Monthly.IsZero()
+}
+
+// BudgetStatus is the spending against one limit in the current period
+type BudgetStatus struct {
+	Period    Period       `json:"period"` // day, week or month
+	Start     time.Time    `json:"start"`  // first day of the current period, local midnight
+	Limit     models.Money `json:"limit"`
+	Spent     models.Money `json:"spent"`
+	Remaining models.Money `json:"remaining"` // Limit minus Spent, negative once exceeded
+	Exceeded  bool         `json:"exceeded"`  // more than Limit was spent
+}
+
+// CheckBudget sums the price of the tickets played in the day, week and month
+// containing now (the draw day of tickets whose time played is unknown) and
+// compares them to the limits that are set, shortest period first
+func CheckBudget(tickets []models.Ticket, limits Limits, now time.Time) []BudgetStatus {
+	var statuses []BudgetStatus
+	for _, l := range []struct {
+		period Period
+		limit  models.Money
+	}{{Day, limits.Daily}, {Week, limits.Weekly}, {Month, limits.Monthly}} {
+		if l.limit.Amount <= 0 {
+			continue
+		}
+
+		key, start := bucketOf(now, l.period)
+		spent := models.RON(0, 0)
+		for _, t := range tickets {
+			at := PlayedAt(t)
+			if at.IsZero() {
+				continue
+			}
+			if k, _ := bucketOf(at.In(now.Location()), l.period); k == key {
+				spent = spent.Add(t.PriceAmount)
+			}
+		}
+
+		statuses = append(statuses, BudgetStatus{
+			Period:    l.period,
+			Start:     start,
+			Limit:     l.limit,
+			Spent:     spent,
+			Remaining: l.limit.Sub(spent),
+			Exceeded:  spent.Amount > l.limit.Amount,
+		})
+	}
+	return statuses
+}
+
+// Exceeded returns the statuses whose limit was exceeded
+func Exceeded(statuses []BudgetStatus) []BudgetStatus {
+	var over []BudgetStatus
+	for _, s := range statuses {
+		if s.Exceeded {
+			over = append(over, s)
+		}
+	}
+	return over
+}
@@ -0,0 +1,55 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+func TestCheckBudget(t *testing.T) {
+	// A Thursday; its ISO week runs from 23.02 to 01.03
+	now := time.Date(2026, 2, 26, 12, 0, 0, 0, time.Local)
+	limits := Limits{Daily: models.RON(10, 0), Weekly: models.RON(5, 0), Monthly: models.RON(50, 0)}
+
+	statuses := CheckBudget(testTickets(), limits, now)
+
+	want := []struct {
+		period   Period
+		start    string
+		spent    models.Money
+		exceeded bool
+	}{
+		{Day, "26.02.2026", models.RON(10, 0), false}, // reaching a limit is not exceeding it
+		{Week, "23.02.2026", models.RON(10, 0), true},
+		{Month, "01.02.2026", models.RON(45, 0), false}, // 02.03.2026 is next month
+	}
+	if len(statuses) != len(want) {
+		t.Fatalf("got %d statuses, want %d: %+v", len(statuses), len(want), statuses)
+	}
+	for i, w := range want {
+		s := statuses[i]
+		if s.Period != w.period || s.Start.Format("02.01.2006") != w.start || s.Spent != w.spent || s.Exceeded != w.exceeded {
+			t.Errorf("status %d = %+v, want %+v", i, s, w)
+		}
+		if s.Remaining != s.Limit.Sub(s.Spent) {
+			t.Errorf("%s remaining = %v, want %v", s.Period, s.Remaining, s.Limit.Sub(s.Spent))
+		}
+	}
+
+	if over := Exceeded(statuses); len(over) != 1 || over[0].Period != Week {
+		t.Errorf("Exceeded = %+v, want the weekly limit", over)
+	}
+}
+
+func TestCheckBudgetUnsetLimits(t *testing.T) {
+	now := time.Date(2026, 2, 26, 12, 0, 0, 0, time.Local)
+	if statuses := CheckBudget(testTickets(), Limits{}, now); len(statuses) != 0 {
+		t.Errorf("CheckBudget without limits = %+v", statuses)
+	}
+
+	statuses := CheckBudget(testTickets(), Limits{Monthly: models.RON(20, 0)}, now)
+	if len(statuses) != 1 || statuses[0].Period != Month || !statuses[0].Exceeded || statuses[0].Remaining != models.RON(-25, 0) {
+		t.Errorf("CheckBudget with a monthly limit = %+v", statuses)
+	}
+}
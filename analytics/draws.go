@@ -0,0 +1,260 @@
+package analytics
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// SumRangeWidth is the width of the ranges draw sums are counted in
+const SumRangeWidth = 20
+
+// NumberStat is how often one number was drawn
+type NumberStat struct {
+	Number     int     `json:"number"`
+	Count      int     `json:"count"`
+	Percent    float64 `json:"percent"`              // of the draws analyzed
+	LastDrawn  string  `json:"last_drawn,omitempty"` // date of the latest draw with the number
+	DrawsSince int     `json:"draws_since"`          // later draws without it; every draw if never drawn
+}
+
+// Combination is a set of numbers drawn together, in increasing order
+type Combination struct {
+	Numbers []int `json:"numbers"`
+	Count   int   `json:"count"`
+}
+
+// Distribution counts draws by a property, e.g. how many of their numbers are odd
+type Distribution struct {
+	Label   string  `json:"label"` // e.g. "4 odd / 2 even"
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// SumRange counts draws whose numbers add up to between From and To, inclusive
+type SumRange struct {
+	From  int `json:"from"`
+	To    int `json:"to"`
+	Count int `json:"count"`
+}
+
+// SumStats describes the sums of the numbers of each draw
+type SumStats struct {
+	Min    int        `json:"min"`
+	Max    int        `json:"max"`
+	Mean   float64    `json:"mean"`
+	Ranges []SumRange `json:"ranges"` // every range from Min to Max, including empty ones
+}
+
+// DrawAnalysis is the statistical view of a game's draws
+type DrawAnalysis struct {
+	Game     models.Game `json:"game"`
+	Draws    int         `json:"draws"`             // draws analyzed
+	Skipped  int         `json:"skipped,omitempty"` // draws left out because they don't fit the game rules
+	From     string      `json:"from,omitempty"`    // date of the oldest draw analyzed
+	To       string      `json:"to,omitempty"`      // date of the latest draw analyzed
+	HighFrom int         `json:"high_from"`         // numbers from HighFrom up count as high
+
+	Numbers  []NumberStat   `json:"numbers"`  // every playable number, in increasing order
+	Hot      []NumberStat   `json:"hot"`      // drawn most often
+	Cold     []NumberStat   `json:"cold"`     // drawn least often
+	Overdue  []NumberStat   `json:"overdue"`  // longest since last drawn
+	Pairs    []Combination  `json:"pairs"`    // drawn together most often
+	Triplets []Combination  `json:"triplets"` // drawn together most often
+	OddEven  []Distribution `json:"odd_even"` // by number of odd numbers, most odd first
+	HighLow  []Distribution `json:"high_low"` // by number of high numbers, most high first
+	Sums     SumStats       `json:"sums"`
+	Joker    []NumberStat   `json:"joker,omitempty"` // Joker numbers, for Joker draws
+}
+
+// AnalyzeDraws computes number frequencies, overdue numbers, co-occurring pairs and
+// triplets, odd/even and high/low distributions and sum ranges over draws of a
+// number-pick game, given newest first as the archive returns them. Hot, cold,
+// overdue and combination lists are cut to top entries. Draws of other games are
+// ignored; those without the game's count of numbers in range are skipped. If last
+// is above 0, only the latest last draws that are not skipped are analyzed.
+func AnalyzeDraws(game models.Game, draws []models.Extraction, last, top int) (DrawAnalysis, error) {
+	rules, ok := models.RulesFor(game)
+	if !ok {
+		return DrawAnalysis{}, fmt.Errorf("%s draws cannot be analyzed (expected Loto 6/49, Loto 5/40 or Joker)", game)
+	}
+
+	a := DrawAnalysis{Game: game, HighFrom: rules.Max/2 + 1}
+	numbers := newNumberStats(rules.Max)
+	joker := newNumberStats(rules.JokerMax)
+	pairs := make(map[[3]int]int) // the third number is always 0
+	triplets := make(map[[3]int]int)
+	odd := make([]int, rules.Drawn+1)
+	high := make([]int, rules.Drawn+1)
+	var sums []int
+
+	for _, d := range draws {
+		if last > 0 && a.Draws == last {
+			break
+		}
+		if d.Game != game {
+			continue
+		}
+		if !validDraw(d.Numbers, rules) {
+			a.Skipped++
+			continue
+		}
+		if a.Draws == 0 {
+			a.To = d.Date
+		}
+		a.From = d.Date
+		a.Draws++
+
+		nums := slices.Sorted(slices.Values(d.Numbers))
+		sum, odds, highs := 0, 0, 0
+		for i, n := range nums {
+			numbers[n-1].drawn(d.Date, a.Draws)
+			sum += n
+			if n%2 == 1 {
+				odds++
+			}
+			if n >= a.HighFrom {
+				highs++
+			}
+			for j := i + 1; j < len(nums); j++ {
+				pairs[[3]int{n, nums[j]}]++
+				for k := j + 1; k < len(nums); k++ {
+					triplets[[3]int{n, nums[j], nums[k]}]++
+				}
+			}
+		}
+		odd[odds]++
+		high[highs]++
+		sums = append(sums, sum)
+
+		if len(joker) > 0 && len(d.Bonus) > 0 && d.Bonus[0] >= 1 && d.Bonus[0] <= rules.JokerMax {
+			joker[d.Bonus[0]-1].drawn(d.Date, a.Draws)
+		}
+	}
+
+	a.Numbers = finishNumberStats(numbers, a.Draws)
+	if rules.JokerMax > 0 {
+		a.Joker = finishNumberStats(joker, a.Draws)
+	}
+
+	a.Hot = topNumbers(a.Numbers, top, func(x, y NumberStat) int { return cmp.Compare(y.Count, x.Count) })
+	a.Cold = topNumbers(a.Numbers, top, func(x, y NumberStat) int { return cmp.Compare(x.Count, y.Count) })
+	a.Overdue = topNumbers(a.Numbers, top, func(x, y NumberStat) int { return cmp.Compare(y.DrawsSince, x.DrawsSince) })
+
+	a.Pairs = topCombinations(pairs, 2, top)
+	a.Triplets = topCombinations(triplets, 3, top)
+
+	for n := rules.Drawn; n >= 0; n-- {
+		a.OddEven = append(a.OddEven, distribution(fmt.Sprintf("%d odd / %d even", n, rules.Drawn-n), odd[n], a.Draws))
+		a.HighLow = append(a.HighLow, distribution(fmt.Sprintf("%d high / %d low", n, rules.Drawn-n), high[n], a.Draws))
+	}
+	a.Sums = sumStats(sums)
+
+	return a, nil
+}
+
+// validDraw reports whether numbers are a complete draw of a game
+func validDraw(numbers []int, rules models.GameRules) bool {
+	if len(numbers) != rules.Drawn {
+		return false
+	}
+	seen := make(map[int]bool)
+	for _, n := range numbers {
+		if n < 1 || n > rules.Max || seen[n] {
+			return false
+		}
+		seen[n] = true
+	}
+	return true
+}
+
+// newNumberStats returns a stat for each number from 1 to max, not yet drawn
+func newNumberStats(max int) []NumberStat {
+	stats := make([]NumberStat, max)
+	for i := range stats {
+		stats[i] = NumberStat{Number: i + 1, DrawsSince: -1}
+	}
+	return stats
+}
+
+// drawn counts a number in the nth draw analyzed, newest first
+func (s *NumberStat) drawn(date string, nth int) {
+	if s.Count == 0 {
+		s.LastDrawn = date
+		s.DrawsSince = nth - 1
+	}
+	s.Count++
+}
+
+// finishNumberStats fills in the percentages and the draws since numbers never drawn
+func finishNumberStats(stats []NumberStat, draws int) []NumberStat {
+	for i := range stats {
+		if stats[i].DrawsSince < 0 {
+			stats[i].DrawsSince = draws
+		}
+		if draws > 0 {
+			stats[i].Percent = float64(stats[i].Count) / float64(draws) * 100
+		}
+	}
+	return stats
+}
+
+// topNumbers returns the first n numbers in the order of compare, ties by number
+func topNumbers(stats []NumberStat, n int, compare func(x, y NumberStat) int) []NumberStat {
+	sorted := slices.Clone(stats)
+	slices.SortStableFunc(sorted, compare)
+	return sorted[:min(n, len(sorted))]
+}
+
+// topCombinations returns the n combinations of size numbers drawn most often,
+// ties in increasing order
+func topCombinations(counts map[[3]int]int, size, n int) []Combination {
+	combos := make([]Combination, 0, len(counts))
+	for k, count := range counts {
+		combos = append(combos, Combination{Numbers: slices.Clone(k[:size]), Count: count})
+	}
+	slices.SortFunc(combos, func(x, y Combination) int {
+		if c := cmp.Compare(y.Count, x.Count); c != 0 {
+			return c
+		}
+		return slices.Compare(x.Numbers, y.Numbers)
+	})
+	return combos[:min(n, len(combos))]
+}
+
+// distribution returns a labelled count and its share of the draws
+func distribution(label string, count, draws int) Distribution {
+	d := Distribution{Label: label, Count: count}
+	if draws > 0 {
+		d.Percent = float64(count) / float64(draws) * 100
+	}
+	return d
+}
+
+// sumStats describes draw sums, counted in ranges of SumRangeWidth aligned to multiples of it
+func sumStats(sums []int) SumStats {
+	s := SumStats{Ranges: []SumRange{}}
+	if len(sums) == 0 {
+		return s
+	}
+
+	s.Min, s.Max = slices.Min(sums), slices.Max(sums)
+	total := 0
+	for _, sum := range sums {
+		total += sum
+	}
+	s.Mean = float64(total) / float64(len(sums))
+
+	for from := s.Min / SumRangeWidth * SumRangeWidth; from <= s.Max; from += SumRangeWidth {
+		r := SumRange{From: from, To: from + SumRangeWidth - 1}
+		for _, sum := range sums {
+			if sum >= r.From && sum <= r.To {
+				r.Count++
+			}
+		}
+		s.Ranges = append(s.Ranges, r)
+	}
+	return s
+}
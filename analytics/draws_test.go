@@ -0,0 +1,140 @@
+package analytics
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// testDraws are Loto 5/40 draws, newest first as the archive returns them
+func testDraws() []models.Extraction {
+	return []models.Extraction{
+		{Game: models.GameLoto540, Date: "05-03-2026", Numbers: []int{6, 5, 4, 3, 2, 1}},
+		{Game: models.GameLoto649, Date: "04-03-2026", Numbers: []int{7, 8, 9, 10, 11, 12}},
+		{Game: models.GameLoto540, Date: "26-02-2026", Numbers: []int{1, 2, 3, 38, 39, 40}},
+		{Game: models.GameLoto540, Date: "19-02-2026", Numbers: []int{1, 2, 3}}, // incomplete
+		{Game: models.GameLoto540, Date: "12-02-2026", Numbers: []int{2, 4, 6, 21, 22, 23}},
+	}
+}
+
+func TestAnalyzeDraws(t *testing.T) {
+	a, err := AnalyzeDraws(models.GameLoto540, testDraws(), 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Draws != 3 || a.Skipped != 1 || a.From != "12-02-2026" || a.To != "05-03-2026" {
+		t.Errorf("draws = %d, skipped %d, from %s to %s; want 3, 1, 12-02-2026 to 05-03-2026", a.Draws, a.Skipped, a.From, a.To)
+	}
+	if len(a.Numbers) != 40 || a.Joker != nil {
+		t.Fatalf("got %d numbers and %d Joker numbers, want 40 and none", len(a.Numbers), len(a.Joker))
+	}
+
+	for _, want := range []NumberStat{
+		{Number: 1, Count: 2, LastDrawn: "05-03-2026", DrawsSince: 0},
+		{Number: 21, Count: 1, LastDrawn: "12-02-2026", DrawsSince: 2},
+		{Number: 7, Count: 0, DrawsSince: 3}, // never drawn
+	} {
+		got := a.Numbers[want.Number-1]
+		got.Percent = 0
+		if got != want {
+			t.Errorf("number %d = %+v, want %+v", want.Number, got, want)
+		}
+	}
+	if a.Hot[0].Number != 2 || a.Hot[0].Count != 3 || a.Hot[0].Percent != 100 {
+		t.Errorf("hottest = %+v, want 2 drawn in every draw", a.Hot[0])
+	}
+	if a.Overdue[0].Number != 7 || len(a.Overdue) != 3 {
+		t.Errorf("overdue = %+v, want 3 starting with 7", a.Overdue)
+	}
+
+	wantPairs := [][]int{{1, 2}, {1, 3}, {2, 3}}
+	wantTriplets := [][]int{{1, 2, 3}, {2, 4, 6}}
+	for i, want := range wantPairs {
+		if !slices.Equal(a.Pairs[i].Numbers, want) || a.Pairs[i].Count != 2 {
+			t.Errorf("pair %d = %+v, want %v twice", i, a.Pairs[i], want)
+		}
+	}
+	if len(a.Triplets) != 3 {
+		t.Fatalf("got %d triplets, want 3", len(a.Triplets))
+	}
+	for i, want := range wantTriplets {
+		if !slices.Equal(a.Triplets[i].Numbers, want) || a.Triplets[i].Count != 2 {
+			t.Errorf("triplet %d = %+v, want %v twice", i, a.Triplets[i], want)
+		}
+	}
+
+	// Two draws have 3 odd numbers, one has 2; high numbers are 21 to 40
+	if len(a.OddEven) != 7 || a.OddEven[3].Label != "3 odd / 3 even" || a.OddEven[3].Count != 2 || a.OddEven[4].Count != 1 {
+		t.Errorf("odd/even = %+v", a.OddEven)
+	}
+	if a.HighFrom != 21 || a.HighLow[3].Label != "3 high / 3 low" || a.HighLow[3].Count != 2 || a.HighLow[6].Count != 1 {
+		t.Errorf("high/low from %d = %+v", a.HighFrom, a.HighLow)
+	}
+
+	s := a.Sums
+	if s.Min != 21 || s.Max != 123 || s.Mean != 74 || len(s.Ranges) != 6 {
+		t.Fatalf("sums = %+v, want 21 to 123, mean 74, in 6 ranges", s)
+	}
+	if r := s.Ranges[0]; r.From != 20 || r.To != 39 || r.Count != 1 {
+		t.Errorf("first sum range = %+v, want 20-39 once", r)
+	}
+	if s.Ranges[1].Count != 0 {
+		t.Errorf("empty sum range = %+v", s.Ranges[1])
+	}
+}
+
+func TestAnalyzeDrawsLast(t *testing.T) {
+	tests := []struct {
+		last           int
+		draws, skipped int
+		from           string
+	}{
+		{1, 1, 0, "05-03-2026"},
+		{2, 2, 0, "26-02-2026"},
+		{3, 3, 1, "12-02-2026"}, // the incomplete draw does not count towards the limit
+		{10, 3, 1, "12-02-2026"},
+	}
+	for _, tt := range tests {
+		a, err := AnalyzeDraws(models.GameLoto540, testDraws(), tt.last, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Draws != tt.draws || a.Skipped != tt.skipped || a.From != tt.from || a.To != "05-03-2026" {
+			t.Errorf("last %d: draws = %d, skipped %d, from %s to %s; want %d, %d, %s to 05-03-2026",
+				tt.last, a.Draws, a.Skipped, a.From, a.To, tt.draws, tt.skipped, tt.from)
+		}
+	}
+}
+
+func TestAnalyzeDrawsJoker(t *testing.T) {
+	draws := []models.Extraction{
+		{Game: models.GameJoker, Date: "05-03-2026", Numbers: []int{1, 2, 3, 4, 5}, Bonus: []int{7}},
+		{Game: models.GameJoker, Date: "01-03-2026", Numbers: []int{41, 42, 43, 44, 45}, Bonus: []int{7}},
+	}
+	a, err := AnalyzeDraws(models.GameJoker, draws, 0, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Joker) != 20 || a.Joker[6].Count != 2 || a.Joker[6].LastDrawn != "05-03-2026" {
+		t.Errorf("Joker number 7 = %+v, want drawn twice", a.Joker)
+	}
+	if a.HighFrom != 23 || a.HighLow[0].Count != 1 || a.HighLow[5].Count != 1 {
+		t.Errorf("high/low from %d = %+v", a.HighFrom, a.HighLow)
+	}
+
+	if _, err := AnalyzeDraws(models.GameNoroc, draws, 0, 5); err == nil {
+		t.Error("AnalyzeDraws accepted Noroc")
+	}
+}
+
+func TestAnalyzeDrawsEmpty(t *testing.T) {
+	a, err := AnalyzeDraws(models.GameLoto649, nil, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Draws != 0 || len(a.Numbers) != 49 || a.Numbers[0].DrawsSince != 0 || len(a.Pairs) != 0 || len(a.Sums.Ranges) != 0 {
+		t.Errorf("AnalyzeDraws(nil) = %+v", a)
+	}
+}
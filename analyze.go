@@ -0,0 +1,173 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rursache/loto-cli/analytics"
+	"github.com/rursache/loto-cli/models"
+)
+
+// histogramWidth is the length of the longest bar of a histogram
+const histogramWidth = 40
+
+// runAnalyzeCmd is the CLI command handler for "analyze"
+func runAnalyzeCmd(args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	game := fs.String("game", string(models.GameLoto649), "game to analyze: Loto 6/49, Loto 5/40 or Joker")
+	from := fs.String("from", "", "only analyze draws on or after this date (DD.MM.YYYY)")
+	to := fs.String("to", "", "only analyze draws on or before this date (DD.MM.YYYY)")
+	last := fs.Int("last", 0, "only analyze the latest N draws (0 = all)")
+	top := fs.Int("top", 10, "entries in the hot, cold, overdue, pair and triplet lists")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if *last < 0 || *top < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --last must be 0 or more and --top at least 1")
+		os.Exit(1)
+	}
+
+	q, err := parseDrawQuery(*game, "", *from, *to)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if q.Game == "" {
+		fmt.Fprintln(os.Stderr, "Error: --game is required")
+		os.Exit(1)
+	}
+
+	// --last counts the draws analyzed, so incomplete ones are skipped before the limit applies
+	draws, err := queryArchivedDraws(q)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading archive: %v\n", err)
+		os.Exit(1)
+	}
+	a, err := analytics.AnalyzeDraws(q.Game, draws, *last, *top)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if structured() {
+		writeStructured("analysis", a)
+		return
+	}
+
+	if a.Draws == 0 {
+		fmt.Printf("No archived %s draws. Run \"loto-cli results\" regularly or \"loto-cli results import <file>\" to fill the archive.\n", a.Game)
+		return
+	}
+	printAnalysis(a)
+}
+
+// printAnalysis prints a draw analysis as tables and histograms
+func printAnalysis(a analytics.DrawAnalysis) {
+	fmt.Printf("=== %s: %d draw(s), %s → %s ===\n", a.Game, a.Draws, a.From, a.To)
+	if a.Skipped > 0 {
+		fmt.Printf("  (%d incomplete draw(s) skipped)\n", a.Skipped)
+	}
+
+	fmt.Println()
+	fmt.Println("=== Number Frequency ===")
+	printNumberHistogram(a.Numbers)
+
+	fmt.Println()
+	fmt.Println("=== Hot Numbers ===")
+	fmt.Printf("  %s\n", formatNumberCounts(a.Hot))
+	fmt.Println("=== Cold Numbers ===")
+	fmt.Printf("  %s\n", formatNumberCounts(a.Cold))
+
+	fmt.Println()
+	fmt.Println("=== Overdue Numbers ===")
+	fmt.Printf("  %6s  %11s  %s\n", "Number", "Draws since", "Last drawn")
+	for _, n := range a.Overdue {
+		last := n.LastDrawn
+		if last == "" {
+			last = "never"
+		}
+		fmt.Printf("  %6d  %11d  %s\n", n.Number, n.DrawsSince, last)
+	}
+
+	fmt.Println()
+	fmt.Println("=== Top Pairs ===")
+	printCombinations(a.Pairs)
+	fmt.Println("=== Top Triplets ===")
+	printCombinations(a.Triplets)
+
+	fmt.Println()
+	fmt.Println("=== Odd / Even ===")
+	printDistribution(a.OddEven)
+	fmt.Println()
+	fmt.Printf("=== High / Low (high: %d and up) ===\n", a.HighFrom)
+	printDistribution(a.HighLow)
+
+	fmt.Println()
+	fmt.Println("=== Sums ===")
+	fmt.Printf("  Min: %d  |  Max: %d  |  Mean: %.1f\n", a.Sums.Min, a.Sums.Max, a.Sums.Mean)
+	most := 0
+	for _, r := range a.Sums.Ranges {
+		most = max(most, r.Count)
+	}
+	for _, r := range a.Sums.Ranges {
+		fmt.Printf("  %3d-%-3d  %-*s %d\n", r.From, r.To, histogramWidth, bar(r.Count, most), r.Count)
+	}
+
+	if len(a.Joker) > 0 {
+		fmt.Println()
+		fmt.Println("=== Joker Number Frequency ===")
+		printNumberHistogram(a.Joker)
+	}
+}
+
+// printNumberHistogram prints a bar per number with its count and share of the draws
+func printNumberHistogram(stats []analytics.NumberStat) {
+	most := 0
+	for _, n := range stats {
+		most = max(most, n.Count)
+	}
+	for _, n := range stats {
+		fmt.Printf("  %2d  %-*s %4d  %5.1f%%\n", n.Number, histogramWidth, bar(n.Count, most), n.Count, n.Percent)
+	}
+}
+
+// printDistribution prints a bar per distribution entry with its count and share of the draws
+func printDistribution(dist []analytics.Distribution) {
+	most := 0
+	for _, d := range dist {
+		most = max(most, d.Count)
+	}
+	for _, d := range dist {
+		fmt.Printf("  %-15s  %-*s %4d  %5.1f%%\n", d.Label, histogramWidth, bar(d.Count, most), d.Count, d.Percent)
+	}
+}
+
+// printCombinations prints numbers drawn together and how often
+func printCombinations(combos []analytics.Combination) {
+	if len(combos) == 0 {
+		fmt.Println("  (none)")
+		return
+	}
+	for _, c := range combos {
+		fmt.Printf("  %-10s %d\n", formatNumbers(c.Numbers), c.Count)
+	}
+}
+
+// formatNumberCounts formats numbers with their counts, e.g. "7 (52), 23 (50)"
+func formatNumberCounts(stats []analytics.NumberStat) string {
+	parts := make([]string, len(stats))
+	for i, n := range stats {
+		parts[i] = fmt.Sprintf("%d (%d)", n.Number, n.Count)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// bar draws a histogram bar for count, the longest one for most
+func bar(count, most int) string {
+	if most == 0 {
+		return ""
+	}
+	return strings.Repeat("#", count*histogramWidth/most)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rursache/loto-cli/client"
+	"github.com/rursache/loto-cli/models"
+	"github.com/rursache/loto-cli/store"
+)
+
+// runSync is the CLI command handler for "sync"
+func runSync(ctx context.Context, c *client.Client) {
+	s, err := store.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening archive: %v\n", err)
+		os.Exit(1)
+	}
+	defer s.Close()
+
+	fmt.Fprintln(os.Stderr, "Syncing ticket history...")
+	res, err := s.Sync(ctx, c)
+	if err != nil {
+		fatal("Error syncing tickets", err)
+	}
+
+	if structured() {
+		writeStructured("sync", res)
+		return
+	}
+
+	fmt.Printf("Fetched %d ticket(s) from %d page(s)\n", res.Fetched, res.Pages)
+	fmt.Printf("  New:     %d\n", res.New)
+	fmt.Printf("  Updated: %d\n", res.Updated)
+	fmt.Printf("  Total:   %d ticket(s) in archive\n", res.Total)
+}
+
+// loadArchivedTickets reads all tickets from the local archive
+func loadArchivedTickets() ([]models.Ticket, error) {
+	s, err := store.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	return s.Tickets()
+}
+
+// withTickets loads the ticket history, either live (logging in) or from the archive with --offline.
+// With details, live tickets are enriched with their detail pages (played lines, Noroc).
+func withTickets(details bool, fn func([]models.Ticket)) {
+	if opts.offline {
+		tickets, err := loadArchivedTickets()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading archive: %v\n", err)
+			os.Exit(1)
+		}
+		if len(tickets) == 0 {
+			fmt.Fprintln(os.Stderr, "The local archive is empty. Run \"loto-cli sync\" first.")
+		}
+		fn(tickets)
+		return
+	}
+
+	withClient(func(ctx context.Context, c *client.Client) {
+		progress := stderrProgress()
+		tickets, err := c.GetAllTickets(ctx, progress)
+		if err := warnDetails(err); err != nil {
+			fatal("Error fetching tickets", err)
+		}
+		if details {
+			if err := warnDetails(c.FillTicketDetails(ctx, tickets, progress)); err != nil {
+				fatal("Error fetching ticket details", err)
+			}
+		}
+		fn(tickets)
+	})
+}
+
+// warnDetails prints a warning for ticket detail pages that failed to load and
+// returns any other error
+func warnDetails(err error) error {
+	var detailsErr *client.DetailsError
+	if !errors.As(err, &detailsErr) {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Warning: %d of %d ticket detail pages failed to load, those tickets lack played numbers and prizes (%v)\n",
+		detailsErr.Failed, detailsErr.Total, detailsErr.Err)
+	return nil
+}
+
+// archiveResults adds freshly fetched results to the draws archive
+func archiveResults(results []models.Extraction) error {
+	s, err := store.Open()
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	_, err = s.SaveDraws(results)
+	return err
+}
+
+// loadArchivedDraws reads one page of archived draws for a game (all games if empty)
+func loadArchivedDraws(game models.Game, offset, limit int) ([]models.Extraction, int, error) {
+	s, err := store.Open()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer s.Close()
+
+	return s.Draws(store.DrawQuery{Game: game, Offset: offset, Limit: limit})
+}
+
+// parseDrawQuery builds an archive query from the results command flags
+func parseDrawQuery(game, date, from, to string) (store.DrawQuery, error) {
+	var q store.DrawQuery
+	var err error
+
+	if game != "" {
+		if q.Game, err = models.ParseGame(game); err != nil {
+			return q, err
+		}
+	}
+
+	if date != "" {
+		if from != "" || to != "" {
+			return q, fmt.Errorf("--date cannot be combined with --from/--to")
+		}
+		from, to = date, date
+	}
+	if from != "" {
+		if q.From, err = models.ParseDrawDate(from); err != nil {
+			return q, fmt.Errorf("--from: %w", err)
+		}
+	}
+	if to != "" {
+		if q.To, err = models.ParseDrawDate(to); err != nil {
+			return q, fmt.Errorf("--to: %w", err)
+		}
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return q, fmt.Errorf("--to is before --from")
+	}
+
+	return q, nil
+}
+
+// runResultsHistory prints a page of archived draws matching q
+func runResultsHistory(q store.DrawQuery, page, perPage int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage > 0 {
+		q.Limit = perPage
+		q.Offset = (page - 1) * perPage
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening archive: %v\n", err)
+		os.Exit(1)
+	}
+	defer s.Close()
+
+	draws, total, err := s.Draws(q)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading archive: %v\n", err)
+		os.Exit(1)
+	}
+
+	if structured() {
+		writeStructured("results", draws)
+		return
+	}
+
+	if total == 0 {
+		fmt.Println("No archived draws match. Run \"loto-cli results\" regularly or \"loto-cli results import <file>\" to fill the archive.")
+		return
+	}
+
+	fmt.Printf("%-12s %-20s %-24s %s\n", "Date", "Game", "Numbers", "Bonus")
+	fmt.Println(strings.Repeat("-", 66))
+
+	for _, ext := range draws {
+		numbers := formatNumbers(ext.Numbers)
+		if ext.Game.IsDigitGame() {
+			numbers = formatNorocNumber(ext.Numbers)
+		}
+		bonus := "-"
+		if len(ext.Bonus) > 0 {
+			bonus = formatNumbers(ext.Bonus)
+		}
+		fmt.Printf("%-12s %-20s %-24s %s\n", ext.Date, ext.Label(), numbers, bonus)
+	}
+
+	fmt.Println(strings.Repeat("-", 66))
+	pages := 1
+	if perPage > 0 {
+		pages = (total + perPage - 1) / perPage
+	}
+	fmt.Printf("Page %d/%d (%d draw(s))\n", page, pages, total)
+}
+
+// runImportResults is the CLI command handler for "results import"
+func runImportResults(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: loto-cli results import <file.csv|file.json>")
+		os.Exit(1)
+	}
+
+	draws, err := store.ReadDrawsFile(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+
+	s, err := store.Open()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening archive: %v\n", err)
+		os.Exit(1)
+	}
+	defer s.Close()
+
+	added, err := s.SaveDraws(draws)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing archive: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Imported %d draw(s), %d new\n", len(draws), added)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/rursache/loto-cli/analytics"
+	"github.com/rursache/loto-cli/config"
+	"github.com/rursache/loto-cli/models"
+)
+
+// budgetReport is the output of "budget"
+type budgetReport struct {
+	Limits   []analytics.BudgetStatus `json:"limits"`
+	Exceeded bool                     `json:"exceeded"` // any limit was exceeded
+}
+
+// runBudgetCmd is the CLI command handler for "budget"
+func runBudgetCmd(args []string) {
+	fs := flag.NewFlagSet("budget", flag.ContinueOnError)
+	check := fs.Bool("check", false, "exit with code 8 if a limit is exceeded")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadOptional()
+	if err != nil {
+		fatal("Error loading config", err)
+	}
+	limits := budgetLimits(cfg)
+	if limits.IsZero() {
+		if structured() {
+			writeStructured("budget", budgetReport{Limits: []analytics.BudgetStatus{}})
+			return
+		}
+		fmt.Println("No budget limits set. Set them with e.g.:")
+		fmt.Println("  loto-cli config set monthly_limit 200")
+		return
+	}
+
+	withTickets(false, func(tickets []models.Ticket) {
+		report := budgetReport{Limits: analytics.CheckBudget(tickets, limits, time.Now())}
+		report.Exceeded = len(analytics.Exceeded(report.Limits)) > 0
+
+		if structured() {
+			writeStructured("budget", report)
+		} else {
+			printBudget(report.Limits)
+		}
+
+		if *check && report.Exceeded {
+			os.Exit(exitBudget)
+		}
+	})
+}
+
+// printBudget prints the spending against each limit
+func printBudget(statuses []analytics.BudgetStatus) {
+	fmt.Println("=== Budget ===")
+	fmt.Printf("  %-8s %-11s %14s %14s %14s\n", "Limit", "Since", "Spent", "Limit", "Left")
+	for _, s := range statuses {
+		state := ""
+		if s.Exceeded {
+			state = "  EXCEEDED"
+		}
+		fmt.Printf("  %-8s %-11s %14s %14s %14s%s\n",
+			s.Period.Adjective(), s.Start.Format("02.01.2006"), ron(s.Spent), ron(s.Limit), ron(s.Remaining), state)
+	}
+}
+
+// warnBudget prints a warning to stderr for every budget limit the tickets exceed.
+// Limits are best effort: a config that cannot be read sets none.
+func warnBudget(tickets []models.Ticket) {
+	cfg, err := config.LoadOptional()
+	if err != nil {
+		return
+	}
+	for _, s := range analytics.Exceeded(analytics.CheckBudget(tickets, budgetLimits(cfg), time.Now())) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", budgetWarning(s))
+	}
+}
+
+// budgetWarning describes an exceeded limit, e.g.
+// "monthly budget exceeded: 210.00 RON spent of 200.00 RON since 01.10.2026"
+func budgetWarning(s analytics.BudgetStatus) string {
+	return fmt.Sprintf("%s budget exceeded: %s spent of %s since %s",
+		s.Period.Adjective(), ron(s.Spent), ron(s.Limit), s.Start.Format("02.01.2006"))
+}
+
+// budgetLimits returns the spending limits set in the config
+func budgetLimits(cfg *config.Config) analytics.Limits {
+	amount := func(lei float64) models.Money {
+		return models.Money{Amount: int64(math.Round(lei * 100)), Currency: models.CurrencyRON}
+	}
+	return analytics.Limits{
+		Daily:   amount(cfg.DailyLimit),
+		Weekly:  amount(cfg.WeeklyLimit),
+		Monthly: amount(cfg.MonthlyLimit),
+	}
+}
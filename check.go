@@ -0,0 +1,482 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rursache/loto-cli/client"
+	"github.com/rursache/loto-cli/config"
+	"github.com/rursache/loto-cli/models"
+	"github.com/rursache/loto-cli/store"
+)
+
+// ticketCheckResult is a ticket with its computed result, as written in structured output
+type ticketCheckResult struct {
+	Ticket   models.Ticket      `json:"ticket"`
+	Check    models.TicketCheck `json:"check"`
+	Mismatch bool               `json:"mismatch"`
+}
+
+// runCheckCmd parses the check command flags and runs it
+func runCheckCmd(args []string) {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	onlyMismatches := fs.Bool("mismatches", false, "only show tickets whose computed result differs from the site")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	check := func(tickets []models.Ticket) {
+		draws, err := queryArchivedDraws(store.DrawQuery{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading archive: %v\n", err)
+			os.Exit(1)
+		}
+		runCheck(tickets, draws, *onlyMismatches)
+	}
+
+	if opts.offline {
+		withTickets(true, check)
+		return
+	}
+
+	withClient(func(ctx context.Context, c *client.Client) {
+		// The latest draw is usually the one recent tickets were played for
+		if results, err := c.GetResults(ctx); err == nil {
+			if err := archiveResults(results); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to archive results: %v\n", err)
+			}
+		}
+
+		progress := stderrProgress()
+		tickets, err := c.GetAllTickets(ctx, progress)
+		if err := warnDetails(err); err != nil {
+			fatal("Error fetching tickets", err)
+		}
+		if err := warnDetails(c.FillTicketDetails(ctx, tickets, progress)); err != nil {
+			fatal("Error fetching ticket details", err)
+		}
+		check(tickets)
+	})
+}
+
+func runCheck(tickets []models.Ticket, draws []models.Extraction, onlyMismatches bool) {
+	var results []ticketCheckResult
+	var checked, mismatches int
+	for _, t := range tickets {
+		check := models.CheckTicket(t, draws)
+		r := ticketCheckResult{Ticket: t, Check: check, Mismatch: check.Mismatch(t.Status)}
+		if check.Checked {
+			checked++
+		}
+		if r.Mismatch {
+			mismatches++
+		}
+		if onlyMismatches && !r.Mismatch {
+			continue
+		}
+		results = append(results, r)
+	}
+
+	if structured() {
+		writeStructured("check", results)
+		return
+	}
+
+	if len(tickets) == 0 {
+		fmt.Println("No tickets found.")
+		return
+	}
+
+	fmt.Printf("%-14s %-12s %-14s %-10s %-16s %s\n", "Game", "Ticket ID", "Draw Date", "Site", "Computed", "")
+	fmt.Println(strings.Repeat("-", 80))
+
+	for _, r := range results {
+		t, check := r.Ticket, r.Check
+
+		computed := "-"
+		note := check.Reason
+		switch {
+		case !check.Checked:
+		case check.Won:
+			computed = "Won " + bestCategories(check)
+			note = ""
+		default:
+			computed = "Lost"
+			note = ""
+		}
+		if r.Mismatch {
+			note = "MISMATCH"
+		}
+
+		fmt.Printf("%-14s %-12s %-14s %-10s %-16s %s\n", t.Game, t.TicketID, t.DrawDate, t.Status.String(), computed, note)
+
+		if !check.Checked {
+			continue
+		}
+		for i, lc := range check.Lines {
+			result := ""
+			if lc.Category != "" {
+				result = "Cat. " + lc.Category
+				if len(lc.Wins) > 1 || (len(lc.Wins) == 1 && lc.Wins[0].Count > 1) {
+					result = formatWins(lc.Wins)
+				}
+			}
+			if lc.Line.Category != "" && lc.Line.Category != lc.Category {
+				result += fmt.Sprintf("  (site: Cat. %s)", lc.Line.Category)
+			}
+			hits := fmt.Sprintf("%d hit(s)", len(lc.Hits))
+			if lc.JokerHit {
+				hits += " + Joker"
+			}
+			fmt.Printf("    %2d. %-28s %-16s %s\n", i+1, formatNumbers(lc.Line.Numbers), hits, result)
+		}
+		for _, nc := range check.Noroc {
+			result := ""
+			if nc.Category != "" {
+				result = "Cat. " + nc.Category
+			}
+			fmt.Printf("    %s: %s vs %s, %d digit(s)  %s\n", nc.Entry.Game, nc.Entry.Number, nc.Drawn, nc.Matched, result)
+		}
+	}
+
+	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("Checked: %d of %d ticket(s)  |  Mismatches: %d\n", checked, len(tickets), mismatches)
+}
+
+// bestCategories lists the best category won by each line and Noroc number, e.g. "(IV, Noroc VI)"
+func bestCategories(check models.TicketCheck) string {
+	var cats []string
+	for _, lc := range check.Lines {
+		if lc.Category != "" {
+			cats = append(cats, lc.Category)
+		}
+	}
+	for _, nc := range check.Noroc {
+		if nc.Category != "" {
+			cats = append(cats, string(nc.Entry.Game)+" "+nc.Category)
+		}
+	}
+	return "(" + strings.Join(cats, ", ") + ")"
+}
+
+// formatWins formats the categories won by a combined variant, e.g. "1x Cat. III, 4x Cat. IV"
+func formatWins(wins []models.CategoryWin) string {
+	parts := make([]string, len(wins))
+	for i, w := range wins {
+		parts[i] = fmt.Sprintf("%dx Cat. %s", w.Count, w.Category)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// paperCheckResult is a hand-entered line checked against a draw, as written in structured output
+type paperCheckResult struct {
+	Game  models.Game        `json:"game"`
+	Draw  models.Extraction  `json:"draw"`
+	Line  *models.LineCheck  `json:"line,omitempty"`
+	Noroc *models.NorocCheck `json:"noroc,omitempty"`
+}
+
+// paperEntry is one hand-entered line: either lotto numbers or a Noroc number
+type paperEntry struct {
+	game  models.Game
+	line  models.PlayedLine
+	noroc string
+}
+
+// runCheckNumbersCmd is the CLI command handler for "check-numbers".
+// It needs no credentials: draws come from the public results page or the archive.
+func runCheckNumbersCmd(args []string) {
+	fs := flag.NewFlagSet("check-numbers", flag.ContinueOnError)
+	gameName := fs.String("game", "", "game played (e.g. \"Loto 6/49\", 649, joker, noroc)")
+	joker := fs.Int("joker", 0, "Joker number (Joker only, or use \"+ N\" after the numbers)")
+	date := fs.String("date", "", "check against the archived draw on this date (DD.MM.YYYY) instead of the latest")
+	file := fs.String("file", "", "check every line of a file: \"[game:] numbers [+ joker]\", # starts a comment")
+	numbers, err := parseInterspersed(fs, args)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	var defaultGame models.Game
+	if *gameName != "" {
+		g, err := models.ParseGame(*gameName)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defaultGame = g
+	}
+
+	var entries []paperEntry
+	if *file != "" {
+		var err error
+		if entries, err = readPaperEntries(*file, defaultGame); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", *file, err)
+			os.Exit(1)
+		}
+	} else {
+		if len(numbers) == 0 {
+			fmt.Fprintln(os.Stderr, "Usage: loto-cli check-numbers --game <game> <numbers...> [--joker N] [--date DD.MM.YYYY]")
+			fmt.Fprintln(os.Stderr, "       loto-cli check-numbers --file <file> [--game <game>] [--date DD.MM.YYYY]")
+			os.Exit(1)
+		}
+		e, err := parsePaperEntry(defaultGame, numbers)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if *joker != 0 {
+			e.line.Joker = *joker
+		}
+		if err := validatePaperEntry(e); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		entries = append(entries, e)
+	}
+
+	draws, err := paperDraws(entries, *date)
+	if err != nil {
+		fatal("Error", err)
+	}
+
+	var results []paperCheckResult
+	for _, e := range entries {
+		draw, ok := draws[e.game]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Warning: no %s draw available, skipping\n", e.game)
+			continue
+		}
+		r := paperCheckResult{Game: e.game, Draw: draw}
+		if rules, ok := models.RulesFor(e.game); ok {
+			lc := models.CheckLine(rules, e.line, draw)
+			r.Line = &lc
+		} else if rules, ok := models.DigitRulesFor(e.game); ok {
+			nc := models.CheckNoroc(rules, models.NorocEntry{Game: e.game, Number: e.noroc}, draw)
+			r.Noroc = &nc
+		}
+		results = append(results, r)
+	}
+
+	if structured() {
+		writeStructured("check-numbers", results)
+		return
+	}
+
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("=== %s (%s) ===\n", r.Game, r.Draw.Date)
+		if r.Line != nil {
+			drawn := formatNumbers(r.Draw.Numbers)
+			if len(r.Draw.Bonus) > 0 {
+				drawn += " + " + formatNumbers(r.Draw.Bonus)
+			}
+			played := formatNumbers(r.Line.Line.Numbers)
+			if r.Line.Line.Joker > 0 {
+				played += fmt.Sprintf(" + %d", r.Line.Line.Joker)
+			}
+			hits := fmt.Sprintf("%d (%s)", len(r.Line.Hits), formatNumbers(r.Line.Hits))
+			if len(r.Line.Hits) == 0 {
+				hits = "0"
+			}
+			if r.Line.JokerHit {
+				hits += " + Joker"
+			}
+			fmt.Printf("Drawn:   %s\n", drawn)
+			fmt.Printf("Played:  %s\n", played)
+			fmt.Printf("Matches: %s\n", hits)
+			fmt.Printf("Prize:   %s\n", prizeLabel(r.Line.Category, r.Line.Wins))
+		}
+		if r.Noroc != nil {
+			fmt.Printf("Drawn:   %s\n", r.Noroc.Drawn)
+			fmt.Printf("Played:  %s\n", r.Noroc.Entry.Number)
+			fmt.Printf("Matches: %d digit(s)\n", r.Noroc.Matched)
+			fmt.Printf("Prize:   %s\n", prizeLabel(r.Noroc.Category, nil))
+		}
+	}
+}
+
+// prizeLabel describes the category won, or that nothing was won
+func prizeLabel(category string, wins []models.CategoryWin) string {
+	switch {
+	case category == "":
+		return "none"
+	case len(wins) > 1 || (len(wins) == 1 && wins[0].Count > 1):
+		return formatWins(wins)
+	default:
+		return "Cat. " + category
+	}
+}
+
+// parsePaperEntry parses hand-entered numbers. Joker numbers follow a "+" and
+// Noroc numbers may be given as one number or as separate digits.
+func parsePaperEntry(game models.Game, tokens []string) (paperEntry, error) {
+	e := paperEntry{game: game}
+	if game == "" {
+		return e, fmt.Errorf("--game is required")
+	}
+
+	// Accept "3 7 12", "3,7,12" and "3 7 12+5" alike
+	text := strings.NewReplacer(",", " ", "+", " + ").Replace(strings.Join(tokens, " "))
+	fields := strings.Fields(text)
+
+	if _, ok := models.DigitRulesFor(game); ok {
+		e.noroc = strings.Join(fields, "")
+		return e, nil
+	}
+
+	jokerNext := false
+	for _, f := range fields {
+		if f == "+" {
+			jokerNext = true
+			continue
+		}
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return e, fmt.Errorf("invalid number %q", f)
+		}
+		if jokerNext {
+			e.line.Joker = n
+			jokerNext = false
+			continue
+		}
+		e.line.Numbers = append(e.line.Numbers, n)
+	}
+
+	return e, nil
+}
+
+// validatePaperEntry checks an entry against its game rules
+func validatePaperEntry(e paperEntry) error {
+	if rules, ok := models.RulesFor(e.game); ok {
+		return rules.ValidateLine(e.line)
+	}
+	if rules, ok := models.DigitRulesFor(e.game); ok {
+		return rules.Validate(e.noroc)
+	}
+	return fmt.Errorf("unsupported game %q", e.game)
+}
+
+// readPaperEntries reads one entry per line: "[game:] numbers [+ joker]"
+func readPaperEntries(path string, defaultGame models.Game) ([]paperEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []paperEntry
+	for n, raw := range strings.Split(string(data), "\n") {
+		line := raw
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		game := defaultGame
+		if i := strings.Index(line, ":"); i >= 0 {
+			g, err := models.ParseGame(line[:i])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+			game = g
+			line = line[i+1:]
+		}
+
+		e, err := parsePaperEntry(game, strings.Fields(line))
+		if err == nil {
+			err = validatePaperEntry(e)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		entries = append(entries, e)
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no numbers found")
+	}
+	return entries, nil
+}
+
+// paperDraws finds the draw to check each entry's game against: the archived
+// draw on date if given, otherwise the latest results from loto.ro, falling back
+// to the latest archived draw when the site can't be reached.
+func paperDraws(entries []paperEntry, date string) (map[models.Game]models.Extraction, error) {
+	draws := make(map[models.Game]models.Extraction)
+
+	if date != "" {
+		q, err := parseDrawQuery("", date, "", "")
+		if err != nil {
+			return nil, err
+		}
+		archived, err := queryArchivedDraws(q)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range archived {
+			if d.Draw == 0 {
+				draws[d.Game] = d
+			}
+		}
+		return draws, nil
+	}
+
+	cfg, err := config.LoadOptional()
+	if err == nil {
+		var c *client.Client
+		var results []models.Extraction
+		if c, err = client.New(cfg); err == nil {
+			ctx, cancel := commandContext(c)
+			results, err = c.GetResults(ctx)
+			cancel()
+		}
+		if err == nil {
+			if err := archiveResults(results); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to archive results: %v\n", err)
+			}
+			for _, d := range results {
+				if d.Draw == 0 {
+					draws[d.Game] = d
+				}
+			}
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not fetch latest results (%v), using the archive\n", err)
+	}
+
+	for _, e := range entries {
+		if _, ok := draws[e.game]; ok {
+			continue
+		}
+		latest, err := queryArchivedDraws(store.DrawQuery{Game: e.game, Limit: 1})
+		if err != nil {
+			return nil, err
+		}
+		if len(latest) > 0 {
+			draws[e.game] = latest[0]
+		}
+	}
+
+	return draws, nil
+}
+
+// queryArchivedDraws returns every archived draw matching q
+func queryArchivedDraws(q store.DrawQuery) ([]models.Extraction, error) {
+	s, err := store.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+
+	draws, _, err := s.Draws(q)
+	return draws, err
+}
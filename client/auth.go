@@ -1,6 +1,8 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,36 +13,47 @@ import (
 )
 
 const (
-	loginURL           = baseBileteURL + "/login"
-	authCheckURL       = baseBileteURL + "/history/ticket?page_no=1"
+	loginPath          = "/login"
+	authCheckPath      = "/history/ticket?page_no=1"
 	authenticatedTitle = "Biletele Mele"
+	csrfTokenSelector  = `meta[name="csrf-token"]`
+	loginFormSelector  = `input[name="password"]`
 )
 
 // Login authenticates with bilete.loto.ro using saved cookies or fresh credentials.
 // It first attempts to restore a previous session from saved cookies.
 // If no valid session exists, it performs a full login using the configured email and password.
-func (c *Client) Login() error {
+func (c *Client) Login(ctx context.Context) error {
+	if err := c.Config.RequireCredentials(); err != nil {
+		return err
+	}
+
 	// Try to restore session from saved cookies
 	if err := c.LoadCookies(); err == nil {
-		if c.IsAuthenticated() {
+		if c.IsAuthenticated(ctx) {
 			return nil
 		}
 	}
 
+	return c.login(ctx)
+}
+
+// login performs a full login with the configured email and password and saves the session cookies
+func (c *Client) login(ctx context.Context) error {
 	// Step 1: GET the login page to obtain the CSRF token
-	csrfToken, err := c.fetchCSRFToken()
+	csrfToken, err := c.fetchCSRFToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch CSRF token: %w", err)
 	}
 
 	// Step 2: POST credentials
-	if err := c.postLogin(csrfToken); err != nil {
+	if err := c.postLogin(ctx, csrfToken); err != nil {
 		return fmt.Errorf("login failed: %w", err)
 	}
 
 	// Verify authentication succeeded
-	if !c.IsAuthenticated() {
-		return fmt.Errorf("login failed: invalid credentials or unexpected response")
+	if !c.IsAuthenticated(ctx) {
+		return fmt.Errorf("login failed: no session after submitting credentials")
 	}
 
 	// Persist the session cookies
@@ -51,23 +64,98 @@ func (c *Client) Login() error {
 	return nil
 }
 
+// relogin logs in again after a request found the session expired. session is the
+// value of c.session when that request started: if another request logged in again
+// since, the new session is used as is.
+func (c *Client) relogin(ctx context.Context, session int) error {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+
+	if c.session != session {
+		return nil
+	}
+	if err := c.login(ctx); err != nil {
+		return err
+	}
+	c.session++
+	return nil
+}
+
+// currentSession returns the number of re-logins so far
+func (c *Client) currentSession() int {
+	c.sessionMu.Lock()
+	defer c.sessionMu.Unlock()
+	return c.session
+}
+
+// getAuthenticated fetches and parses a bilete.loto.ro page that needs a session.
+// A page redirected to the login form, showing the login form, or, if title is set,
+// without title in its <title> means the session expired: the client logs in again
+// with the configured credentials, saves the new cookies and retries once.
+// Without credentials, or if the retry fails the same way, it returns ErrSessionExpired.
+func (c *Client) getAuthenticated(ctx context.Context, rawURL, referer, page, title string) (*goquery.Document, error) {
+	for retried := false; ; retried = true {
+		session := c.currentSession()
+
+		doc, err := c.getSessionPage(ctx, rawURL, referer, page, title)
+		if !errors.Is(err, ErrSessionExpired) || retried || c.Config.RequireCredentials() != nil {
+			return doc, err
+		}
+
+		if err := c.relogin(ctx, session); err != nil {
+			return nil, fmt.Errorf("session expired and logging in again failed: %w", err)
+		}
+	}
+}
+
+// getSessionPage makes a single attempt of getAuthenticated
+func (c *Client) getSessionPage(ctx context.Context, rawURL, referer, page, title string) (*goquery.Document, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if referer != "" {
+		req.Header.Set("Referer", referer)
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if redirectedToLogin(resp) {
+		return nil, ErrSessionExpired
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s page", resp.StatusCode, page)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, &ParseError{Page: page, Err: err}
+	}
+
+	if doc.Find(loginFormSelector).Length() > 0 {
+		return nil, ErrSessionExpired
+	}
+	if title != "" && !strings.Contains(doc.Find("title").First().Text(), title) {
+		return nil, ErrSessionExpired
+	}
+
+	return doc, nil
+}
+
 // IsAuthenticated checks whether the current session is still valid by requesting
 // the ticket history page and inspecting the page title.
 // Returns true if the session is authenticated, false otherwise.
-func (c *Client) IsAuthenticated() bool {
-	req, err := c.newRequest(http.MethodGet, authCheckURL)
+func (c *Client) IsAuthenticated(ctx context.Context) bool {
+	// Don't follow redirects - a 302 to /login means session expired
+	req, err := c.newRequest(withoutRedirects(ctx), http.MethodGet, c.bileteBase+authCheckPath)
 	if err != nil {
 		return false
 	}
 
-	// Don't follow redirects - a 302 to /login means session expired
-	c.HTTP.CheckRedirect = func(req *http.Request, via []*http.Request) error {
-		return http.ErrUseLastResponse
-	}
-	defer func() {
-		c.HTTP.CheckRedirect = nil
-	}()
-
 	resp, err := c.doRequest(req)
 	if err != nil {
 		return false
@@ -94,8 +182,8 @@ func (c *Client) IsAuthenticated() bool {
 
 // fetchCSRFToken performs a GET request to the login page and extracts the CSRF token
 // from the <meta name="csrf-token"> tag.
-func (c *Client) fetchCSRFToken() (string, error) {
-	req, err := c.newRequest(http.MethodGet, loginURL)
+func (c *Client) fetchCSRFToken(ctx context.Context) (string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, c.bileteBase+loginPath)
 	if err != nil {
 		return "", err
 	}
@@ -112,32 +200,32 @@ func (c *Client) fetchCSRFToken() (string, error) {
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse login page: %w", err)
+		return "", &ParseError{Page: "login", Err: err}
 	}
 
-	token, exists := doc.Find(`meta[name="csrf-token"]`).Attr("content")
+	token, exists := doc.Find(csrfTokenSelector).Attr("content")
 	if !exists || token == "" {
-		return "", fmt.Errorf("CSRF token not found on login page")
+		return "", &ParseError{Page: "login", Selector: csrfTokenSelector}
 	}
 
 	return token, nil
 }
 
 // postLogin submits the login form with the CSRF token and user credentials.
-func (c *Client) postLogin(csrfToken string) error {
+func (c *Client) postLogin(ctx context.Context, csrfToken string) error {
 	formData := url.Values{
 		"_token":   {csrfToken},
 		"email":    {c.Config.Email},
 		"password": {c.Config.Password},
 	}
 
-	req, err := c.newRequest(http.MethodPost, loginURL)
+	req, err := c.newRequest(ctx, http.MethodPost, c.bileteBase+loginPath)
 	if err != nil {
 		return err
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Set("Referer", loginURL)
+	req.Header.Set("Referer", c.bileteBase+loginPath)
 	req.Body = io.NopCloser(strings.NewReader(formData.Encode()))
 	req.ContentLength = int64(len(formData.Encode()))
 
@@ -153,5 +241,10 @@ func (c *Client) postLogin(csrfToken string) error {
 		return fmt.Errorf("unexpected status %d after login POST", resp.StatusCode)
 	}
 
+	// Rejected credentials redirect back to the login form
+	if redirectedToLogin(resp) {
+		return ErrInvalidCredentials
+	}
+
 	return nil
 }
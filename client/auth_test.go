@@ -0,0 +1,184 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/rursache/loto-cli/config"
+	"github.com/rursache/loto-cli/models"
+)
+
+func TestLogin(t *testing.T) {
+	site := newFakeBilete(t, map[string]string{"1": "bilete/history_page1.html"})
+	c := newTestClient(t, nil, site)
+
+	if err := c.Login(t.Context()); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if site.logins != 1 {
+		t.Errorf("logins = %d, want 1", site.logins)
+	}
+
+	path, _ := getCookiesPath()
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("cookies not saved: %v", err)
+	}
+}
+
+func TestLoginReusesSavedCookies(t *testing.T) {
+	site := newFakeBilete(t, map[string]string{"1": "bilete/history_page1.html"})
+	c := newTestClient(t, nil, site)
+	if err := c.Login(t.Context()); err != nil {
+		t.Fatalf("first Login: %v", err)
+	}
+
+	// A new client in the same home restores the session without posting credentials
+	again, err := New(c.Config, WithBaseURLs(c.lotoBase, c.bileteBase))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := again.Login(t.Context()); err != nil {
+		t.Fatalf("second Login: %v", err)
+	}
+	if site.logins != 1 {
+		t.Errorf("logins = %d, want 1 (session should be restored from cookies)", site.logins)
+	}
+}
+
+func TestLoginInvalidCredentials(t *testing.T) {
+	site := newFakeBilete(t, nil)
+	c := newTestClient(t, nil, site)
+	c.Config.Password = "wrong"
+
+	if err := c.Login(t.Context()); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("Login error = %v, want ErrInvalidCredentials", err)
+	}
+	if site.logins != 0 {
+		t.Errorf("logins = %d, want 0", site.logins)
+	}
+}
+
+func TestLoginMissingCredentials(t *testing.T) {
+	site := newFakeBilete(t, nil)
+	c := newTestClient(t, nil, site)
+	c.Config.Email = ""
+
+	if err := c.Login(t.Context()); !errors.Is(err, config.ErrCredentialsMissing) {
+		t.Fatalf("Login error = %v, want config.ErrCredentialsMissing", err)
+	}
+	if len(site.requests) != 0 {
+		t.Errorf("requests = %v, want none", site.requests)
+	}
+}
+
+func TestIsAuthenticated(t *testing.T) {
+	site := newFakeBilete(t, map[string]string{"1": "bilete/history_page1.html"})
+	c := newTestClient(t, nil, site)
+
+	if c.IsAuthenticated(t.Context()) {
+		t.Fatal("IsAuthenticated before login = true, want false")
+	}
+	if err := c.Login(t.Context()); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	if !c.IsAuthenticated(t.Context()) {
+		t.Fatal("IsAuthenticated after login = false, want true")
+	}
+}
+
+func TestLoginMissingCSRFToken(t *testing.T) {
+	c := newTestClient(t, nil, pages(t, map[string]string{"/login": "loto/results_standard.html"}))
+
+	err := c.Login(t.Context())
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Selector != csrfTokenSelector {
+		t.Fatalf("Login error = %v, want a ParseError for the CSRF token", err)
+	}
+}
+
+func TestReloginMidOperation(t *testing.T) {
+	for _, inPlace := range []bool{false, true} {
+		site := newFakeBilete(t, historyPages)
+		site.loginInPlace = inPlace
+		c := loggedInClient(t, site)
+		path, _ := getCookiesPath()
+		before, _ := os.ReadFile(path)
+
+		// The session expires while paging, once
+		expired := false
+		site.before = func(f *fakeBilete, r *http.Request) {
+			if r.URL.Query().Get("page_no") == "2" && !expired {
+				expired = true
+				f.expire()
+			}
+		}
+
+		tickets, err := c.GetAllTickets(t.Context(), nil)
+		if err != nil {
+			t.Fatalf("loginInPlace=%v: GetAllTickets: %v", inPlace, err)
+		}
+		if len(tickets) != 14 {
+			t.Errorf("loginInPlace=%v: got %d tickets, want 14", inPlace, len(tickets))
+		}
+		if site.logins != 2 {
+			t.Errorf("loginInPlace=%v: logins = %d, want 2", inPlace, site.logins)
+		}
+		if after, _ := os.ReadFile(path); string(after) == string(before) {
+			t.Errorf("loginInPlace=%v: cookies.json not refreshed", inPlace)
+		}
+	}
+}
+
+func TestReloginOnlyOnce(t *testing.T) {
+	// Page 5 always drops the session: one new login, then give up
+	site := newFakeBilete(t, historyPages)
+	c := loggedInClient(t, site)
+	site.before = func(f *fakeBilete, r *http.Request) {
+		if r.URL.Query().Get("page_no") == "5" {
+			f.expire()
+		}
+	}
+
+	if _, _, err := c.GetTickets(t.Context(), 5); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("GetTickets error = %v, want ErrSessionExpired", err)
+	}
+	if site.logins != 2 {
+		t.Errorf("logins = %d, want 2", site.logins)
+	}
+}
+
+func TestReloginConcurrent(t *testing.T) {
+	// Every detail page finds the session expired at once; a single new login serves them all
+	site := newFakeBilete(t, historyPages)
+	c := loggedInClient(t, site)
+	site.expire()
+
+	tickets := []models.Ticket{
+		{TicketID: "700010", DetailURL: "/ticket/details/700010"},
+		{TicketID: "700012", DetailURL: "/ticket/details/700012"},
+	}
+	if err := c.FillTicketDetails(t.Context(), tickets, nil); err != nil {
+		t.Fatalf("FillTicketDetails: %v", err)
+	}
+	for _, tk := range tickets {
+		if len(tk.Lines) == 0 {
+			t.Errorf("ticket %s: no played lines", tk.TicketID)
+		}
+	}
+	if site.logins != 2 {
+		t.Errorf("logins = %d, want 2", site.logins)
+	}
+}
+
+func TestReloginInvalidCredentials(t *testing.T) {
+	site := newFakeBilete(t, historyPages)
+	c := loggedInClient(t, site)
+	site.expire()
+	c.Config.Password = "changed"
+
+	if _, _, err := c.GetTickets(t.Context(), 1); !errors.Is(err, ErrInvalidCredentials) {
+		t.Fatalf("GetTickets error = %v, want ErrInvalidCredentials", err)
+	}
+}
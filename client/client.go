@@ -1,17 +1,21 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"strings"
+	"sync"
 
 	"github.com/rursache/loto-cli/config"
 )
 
 const (
-	baseLotoURL   = "https://www.loto.ro"
-	baseBileteURL = "https://bilete.loto.ro"
+	defaultLotoURL   = "https://www.loto.ro"
+	defaultBileteURL = "https://bilete.loto.ro"
 )
 
 // Client is the HTTP client for interacting with loto.ro and bilete.loto.ro
@@ -19,29 +23,95 @@ type Client struct {
 	HTTP      *http.Client
 	Config    *config.Config
 	cookieJar *cookiejar.Jar
+
+	// Base URLs of loto.ro and bilete.loto.ro, without a trailing slash
+	lotoBase   string
+	bileteBase string
+
+	concurrency int // pages fetched at once by GetAllTickets and FillTicketDetails
+
+	// Re-logins after the session expired mid-operation; session counts them so
+	// concurrent requests that all found the session expired log in only once
+	sessionMu sync.Mutex
+	session   int
+}
+
+// Option customizes a Client created by New
+type Option func(*Client)
+
+// WithBaseURLs points the client at other hosts serving the loto.ro and
+// bilete.loto.ro pages, e.g. an httptest server with recorded pages
+func WithBaseURLs(loto, bilete string) Option {
+	return func(c *Client) {
+		c.lotoBase = strings.TrimSuffix(loto, "/")
+		c.bileteBase = strings.TrimSuffix(bilete, "/")
+	}
+}
+
+// WithTransport replaces the HTTP transport, including the configured proxy.
+// Rate limiting, timeouts and retries still apply.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.HTTP.Transport = rt
+	}
 }
 
-// New creates a new Client with a cookie jar and the given config
-func New(cfg *config.Config) (*Client, error) {
+// New creates a new Client with a cookie jar and the given config.
+// The config needs no credentials unless Login is called; a nil config uses the defaults.
+func New(cfg *config.Config, opts ...Option) (*Client, error) {
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = config.DefaultUserAgent
+	}
+
 	jar, err := cookiejar.New(nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
 	}
 
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", cfg.Proxy)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
 	client := &Client{
 		HTTP: &http.Client{
-			Jar: jar,
+			Jar:           jar,
+			Transport:     transport,
+			CheckRedirect: checkRedirect,
 		},
-		Config:    cfg,
-		cookieJar: jar,
+		Config:      cfg,
+		cookieJar:   jar,
+		lotoBase:    defaultLotoURL,
+		bileteBase:  defaultBileteURL,
+		concurrency: cfg.ConcurrencyLimit(),
 	}
 
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.HTTP.Transport = newRetryTransport(client.HTTP.Transport, cfg)
+
 	return client, nil
 }
 
+// resolveBilete turns a link found on a bilete.loto.ro page into an absolute URL
+func (c *Client) resolveBilete(link string) string {
+	if strings.HasPrefix(link, "/") {
+		return c.bileteBase + link
+	}
+	return link
+}
+
 // newRequest creates a new HTTP request with standard browser headers
-func (c *Client) newRequest(method, rawURL string) (*http.Request, error) {
-	req, err := http.NewRequest(method, rawURL, nil)
+func (c *Client) newRequest(ctx context.Context, method, rawURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -56,21 +126,54 @@ func (c *Client) newRequest(method, rawURL string) (*http.Request, error) {
 	return req, nil
 }
 
-// doRequest executes a request and checks for geo-blocking
+// doRequest executes a request and checks for geo-blocking and rate limiting.
+// Retries are left to the transport, so a 429 here means loto.ro kept refusing.
 func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
 	resp, err := c.HTTP.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode == http.StatusGone {
+	switch resp.StatusCode {
+	case http.StatusGone:
 		resp.Body.Close()
-		return nil, fmt.Errorf("loto.ro requires a Romanian IP address. Please connect from Romania or use a VPN")
+		return nil, ErrGeoBlocked
+	case http.StatusTooManyRequests:
+		resp.Body.Close()
+		return nil, ErrRateLimited
 	}
 
 	return resp, nil
 }
 
+// noRedirectsKey marks the context of requests that must not follow redirects
+type noRedirectsKey struct{}
+
+// withoutRedirects returns a context whose requests return redirect responses
+// instead of following them. Unlike changing HTTP.CheckRedirect, it is safe while
+// other requests are in flight.
+func withoutRedirects(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRedirectsKey{}, true)
+}
+
+// checkRedirect follows up to 10 redirects, like http.Client does by default,
+// unless the request was created with withoutRedirects
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if req.Context().Value(noRedirectsKey{}) != nil {
+		return http.ErrUseLastResponse
+	}
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	return nil
+}
+
+// redirectedToLogin reports whether a bilete.loto.ro request was redirected to the
+// login form, which is where the site sends requests without a valid session
+func redirectedToLogin(resp *http.Response) bool {
+	return resp.Request != nil && resp.Request.Response != nil && resp.Request.URL.Path == loginPath
+}
+
 // SetCookies sets cookies on the client's jar for a given URL
 func (c *Client) SetCookies(rawURL string, cookies []*http.Cookie) error {
 	u, err := url.Parse(rawURL)
@@ -0,0 +1,325 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rursache/loto-cli/config"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+const (
+	testEmail    = "player@example.com"
+	testPassword = "s3cret"
+	testCSRF     = "tok-3f9a1c" // the token in testdata/bilete/login.html
+	testSession  = "laravel_session"
+)
+
+// TestMain pins the local time zone, since dates from loto.ro are parsed as local time
+// and the golden files hold them with their offset
+func TestMain(m *testing.M) {
+	time.Local = time.UTC
+	os.Exit(m.Run())
+}
+
+// fixture returns the contents of a recorded page in testdata
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	return data
+}
+
+// servePage writes a recorded page as an HTML response
+func servePage(t *testing.T, w http.ResponseWriter, name string) {
+	t.Helper()
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	w.Write(fixture(t, name))
+}
+
+// pages serves fixed recorded pages by request path; other paths are 404
+func pages(t *testing.T, routes map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		servePage(t, w, name)
+	})
+}
+
+// fakeBilete is a stand-in for bilete.loto.ro: a login form guarded by a CSRF token,
+// and history and detail pages that need a session cookie
+type fakeBilete struct {
+	t       *testing.T
+	history map[string]string // page_no -> fixture
+
+	// loginInPlace serves the login form to requests without a session, instead
+	// of redirecting them to it
+	loginInPlace bool
+	// before, if set, is called with every request before checking the session
+	before func(f *fakeBilete, r *http.Request)
+
+	mu       sync.Mutex
+	session  string         // value of the current session cookie, empty before logging in
+	logins   int            // successful login POSTs
+	requests map[string]int // requests per path
+}
+
+func newFakeBilete(t *testing.T, history map[string]string) *fakeBilete {
+	return &fakeBilete{t: t, history: history, requests: make(map[string]int)}
+}
+
+// expire ends the current session, as the site does after a while
+func (f *fakeBilete) expire() {
+	f.session = ""
+}
+
+func (f *fakeBilete) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests[r.URL.Path]++
+	if f.before != nil {
+		f.before(f, r)
+	}
+
+	if r.URL.Path == "/login" {
+		if r.Method == http.MethodGet {
+			servePage(f.t, w, "bilete/login.html")
+			return
+		}
+		r.ParseForm()
+		if r.PostForm.Get("_token") != testCSRF {
+			http.Error(w, "Page Expired", 419)
+			return
+		}
+		if r.PostForm.Get("email") != testEmail || r.PostForm.Get("password") != testPassword {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		f.logins++
+		f.session = fmt.Sprintf("valid-%d", f.logins)
+		http.SetCookie(w, &http.Cookie{Name: testSession, Value: f.session, Path: "/", Expires: time.Now().Add(2 * time.Hour), HttpOnly: true})
+		http.Redirect(w, r, "/history/ticket", http.StatusFound)
+		return
+	}
+
+	if c, err := r.Cookie(testSession); err != nil || f.session == "" || c.Value != f.session {
+		if f.loginInPlace {
+			servePage(f.t, w, "bilete/login.html")
+			return
+		}
+		http.Redirect(w, r, "/login", http.StatusFound)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/history/ticket":
+		page := r.URL.Query().Get("page_no")
+		if page == "" {
+			page = "1"
+		}
+		name, ok := f.history[page]
+		if !ok {
+			name = "bilete/history_empty.html"
+		}
+		servePage(f.t, w, name)
+	case filepath.Dir(r.URL.Path) == "/ticket/details":
+		name := "bilete/details_" + filepath.Base(r.URL.Path) + ".html"
+		if _, err := os.Stat(filepath.Join("testdata", name)); err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		servePage(f.t, w, name)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// newTestClient returns a client pointed at stand-ins for loto.ro and bilete.loto.ro.
+// HOME is moved to a temporary directory so saved cookies stay out of the real config.
+func newTestClient(t *testing.T, loto, bilete http.Handler) *Client {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	configDir, _ := config.GetConfigDir()
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if loto == nil {
+		loto = http.NotFoundHandler()
+	}
+	if bilete == nil {
+		bilete = http.NotFoundHandler()
+	}
+	lotoSrv := httptest.NewServer(loto)
+	t.Cleanup(lotoSrv.Close)
+	bileteSrv := httptest.NewServer(bilete)
+	t.Cleanup(bileteSrv.Close)
+
+	cfg := &config.Config{Email: testEmail, Password: testPassword, RequestsPerSecond: 1000, RetryDelay: 0.001}
+	c, err := New(cfg, WithBaseURLs(lotoSrv.URL, bileteSrv.URL))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return c
+}
+
+// assertGolden compares v, encoded as indented JSON, with testdata/golden/<name>.json.
+// Run "go test ./client -update" to rewrite the golden files after an intended change.
+func assertGolden(t *testing.T, name string, v any) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("encoding result: %v", err)
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("writing golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("result differs from %s (run with -update if the change is intended)\n got:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestGeoBlocked(t *testing.T) {
+	blocked := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	c := newTestClient(t, blocked, blocked)
+
+	if _, err := c.GetResults(t.Context()); !errors.Is(err, ErrGeoBlocked) {
+		t.Fatalf("GetResults error = %v, want ErrGeoBlocked", err)
+	}
+	if err := c.Login(t.Context()); !errors.Is(err, ErrGeoBlocked) {
+		t.Fatalf("Login error = %v, want ErrGeoBlocked", err)
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	var attempts atomic.Int32
+	limited := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	c := newTestClient(t, limited, nil)
+
+	if _, err := c.GetResults(t.Context()); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("GetResults error = %v, want ErrRateLimited", err)
+	}
+	if n := attempts.Load(); n != 1+config.DefaultRetries {
+		t.Errorf("%d attempts, want %d", n, 1+config.DefaultRetries)
+	}
+}
+
+func TestWithTransport(t *testing.T) {
+	srv := httptest.NewServer(pages(t, map[string]string{"/": "loto/results_standard.html"}))
+	defer srv.Close()
+
+	// Route every request to the test server regardless of host
+	var hosts []string
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		hosts = append(hosts, req.URL.Host)
+		req = req.Clone(req.Context())
+		req.URL.Scheme = "http"
+		req.URL.Host = srv.Listener.Addr().String()
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	t.Setenv("HOME", t.TempDir())
+	c, err := New(nil, WithTransport(rt))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	results, err := c.GetResults(t.Context())
+	if err != nil {
+		t.Fatalf("GetResults: %v", err)
+	}
+	if len(results) == 0 {
+		t.Fatal("GetResults: no results")
+	}
+	if len(hosts) != 1 || hosts[0] != "www.loto.ro" {
+		t.Errorf("requested hosts = %v, want [www.loto.ro]", hosts)
+	}
+}
+
+func TestContextCancellation(t *testing.T) {
+	// A hung server: the handler only returns once the client gives up
+	hung := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	c := newTestClient(t, hung, nil)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetResults(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetResults error = %v, want context.DeadlineExceeded", err)
+	}
+
+	ctx, cancel = context.WithCancel(t.Context())
+	cancel()
+	if _, err := c.GetPrizeReports(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetPrizeReports error = %v, want context.Canceled", err)
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	c, err := New(&config.Config{RequestTimeout: 7})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if timeout := c.HTTP.Transport.(*retryTransport).timeout; timeout != 7*time.Second {
+		t.Errorf("request timeout = %v, want 7s", timeout)
+	}
+
+	c, _ = New(nil)
+	if timeout := c.HTTP.Transport.(*retryTransport).timeout; timeout != config.DefaultRequestTimeout {
+		t.Errorf("default request timeout = %v, want %v", timeout, config.DefaultRequestTimeout)
+	}
+
+	// A hung server fails each attempt after the timeout, as a network error
+	hung := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	c = newTestClient(t, hung, nil)
+	c.HTTP.Transport.(*retryTransport).timeout = 20 * time.Millisecond
+	_, err = c.GetResults(t.Context())
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("GetResults error = %v, want a timeout", err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -23,13 +23,13 @@ type savedCookie struct {
 	HttpOnly bool      `json:"http_only"`
 }
 
-// getCookiesPath returns the path to the cookies file
+// getCookiesPath returns the path to the cookies file of the active profile
 func getCookiesPath() (string, error) {
-	configDir, err := config.GetConfigDir()
+	profileDir, err := config.GetProfileDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(configDir, cookiesFileName), nil
+	return filepath.Join(profileDir, cookiesFileName), nil
 }
 
 // LoadCookies reads saved cookies from disk and applies them to the client
@@ -54,7 +54,8 @@ func (c *Client) LoadCookies() error {
 
 	var cookies []*http.Cookie
 	for _, sc := range saved {
-		if sc.Expires.Before(time.Now()) {
+		// The cookie jar does not report expiry, so saved cookies usually have none
+		if !sc.Expires.IsZero() && sc.Expires.Before(time.Now()) {
 			continue // skip expired cookies
 		}
 		cookies = append(cookies, &http.Cookie{
@@ -69,7 +70,7 @@ func (c *Client) LoadCookies() error {
 	}
 
 	if len(cookies) > 0 {
-		return c.SetCookies(baseBileteURL, cookies)
+		return c.SetCookies(c.bileteBase, cookies)
 	}
 
 	return nil
@@ -77,7 +78,7 @@ func (c *Client) LoadCookies() error {
 
 // SaveCookies writes the current session cookies to disk
 func (c *Client) SaveCookies() error {
-	cookies, err := c.GetCookies(baseBileteURL)
+	cookies, err := c.GetCookies(c.bileteBase)
 	if err != nil {
 		return err
 	}
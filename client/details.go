@@ -0,0 +1,284 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/rursache/loto-cli/models"
+)
+
+var (
+	numberPattern      = regexp.MustCompile(`\d+`)
+	norocNumberPattern = regexp.MustCompile(`\b(?:\d{6,7}|\d(?: \d){5,6})\b`)
+	categoryPattern    = regexp.MustCompile(`(?i)cat(?:egoria|\.)?\s*([IVX]+|\d+)`)
+)
+
+// GetTicketDetails fetches a ticket detail page and extracts the played lines,
+// Noroc participations and the total prize.
+//
+// The detail page lists the played variants in a table:
+//
+//	<thead>: column labels, e.g. "Varianta", "Numere jucate", "Joker", "Categorie", "Câștig"
+//	<tbody>: one row per variant; the numbers cell holds one element (or token) per number
+//	<tfoot>: "TOTAL CÂȘTIG" row whose last <th> holds the total prize
+//
+// Noroc, Super Noroc and Noroc Plus participations are shown as a label followed by
+// the played number (6-7 digits, possibly spaced).
+func (c *Client) GetTicketDetails(ctx context.Context, detailURL string) (*models.TicketDetails, error) {
+	referer := c.bileteBase + ticketHistoryPath + "?page_no=1"
+	doc, err := c.getAuthenticated(ctx, c.resolveBilete(detailURL), referer, "ticket details", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTicketDetails(doc), nil
+}
+
+// FillTicketDetails fetches the detail page of every ticket without played lines
+// and applies it, several pages at once. Tickets whose detail page fails to load
+// are left unchanged and counted in a *DetailsError; any other error means ctx is done.
+func (c *Client) FillTicketDetails(ctx context.Context, tickets []models.Ticket, progress ProgressFunc) error {
+	var missing []int
+	for i := range tickets {
+		if len(tickets[i].Lines) == 0 && tickets[i].DetailURL != "" {
+			missing = append(missing, i)
+		}
+	}
+	return c.fillDetails(ctx, tickets, missing, progress)
+}
+
+// fillDetails fetches and applies the detail pages of tickets[i] for every i in indexes.
+// A failed page only leaves its ticket without details; failures are returned as a
+// *DetailsError once every page was tried.
+func (c *Client) fillDetails(ctx context.Context, tickets []models.Ticket, indexes []int, progress ProgressFunc) error {
+	var mu sync.Mutex
+	failed := &DetailsError{Total: len(indexes)}
+
+	err := c.forEach(ctx, len(indexes), StageDetails, progress, func(ctx context.Context, i int) error {
+		t := &tickets[indexes[i]]
+		details, err := c.GetTicketDetails(ctx, t.DetailURL)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			mu.Lock()
+			if failed.Failed == 0 {
+				failed.Err = fmt.Errorf("ticket %s: %w", t.TicketID, err)
+			}
+			failed.Failed++
+			mu.Unlock()
+			return nil
+		}
+		details.Apply(t)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if failed.Failed > 0 {
+		return failed
+	}
+	return nil
+}
+
+// GetTicketPrize fetches a ticket detail page and extracts the total prize amount.
+// The prize is in a <tfoot> row with "TOTAL CÂȘTIG" label.
+func (c *Client) GetTicketPrize(ctx context.Context, detailURL string) (string, error) {
+	details, err := c.GetTicketDetails(ctx, detailURL)
+	if err != nil {
+		return "", err
+	}
+	return details.Prize, nil
+}
+
+// parseTicketDetails extracts everything known from a ticket detail page
+func parseTicketDetails(doc *goquery.Document) *models.TicketDetails {
+	details := &models.TicketDetails{
+		Prize: parseTotalPrize(doc),
+		Noroc: parseNorocEntries(doc),
+	}
+
+	doc.Find("table").Each(func(_ int, table *goquery.Selection) {
+		details.Lines = append(details.Lines, parsePlayedLines(table)...)
+	})
+
+	return details
+}
+
+// parseTotalPrize reads the prize from the "TOTAL" row in the table footer
+func parseTotalPrize(doc *goquery.Document) string {
+	var prize string
+	doc.Find("tfoot tr").Each(func(_ int, row *goquery.Selection) {
+		text := strings.TrimSpace(row.Text())
+		if strings.Contains(text, "TOTAL") {
+			// The last <th> in the row contains the prize amount
+			row.Find("th").Each(func(_ int, th *goquery.Selection) {
+				val := strings.TrimSpace(th.Text())
+				if strings.Contains(val, "RON") {
+					prize = val
+				}
+			})
+		}
+	})
+	return prize
+}
+
+// lineColumns maps the role of each column in a variants table to its index (-1 if absent)
+type lineColumns struct {
+	numbers, joker, category, prize int
+}
+
+// detectLineColumns identifies the variant table columns from the header labels
+func detectLineColumns(table *goquery.Selection) lineColumns {
+	cols := lineColumns{numbers: -1, joker: -1, category: -1, prize: -1}
+	table.Find("thead th, thead td").Each(func(i int, th *goquery.Selection) {
+		label := strings.ToLower(strings.TrimSpace(th.Text()))
+		switch {
+		case strings.Contains(label, "joker"):
+			cols.joker = i
+		case strings.Contains(label, "numer") || strings.Contains(label, "combina"):
+			cols.numbers = i
+		case strings.Contains(label, "categ"):
+			cols.category = i
+		case strings.Contains(label, "câștig") || strings.Contains(label, "castig"):
+			cols.prize = i
+		}
+	})
+	return cols
+}
+
+// parsePlayedLines extracts one PlayedLine per body row of a variants table.
+// Tables without a numbers column (e.g. order summaries) yield no lines.
+func parsePlayedLines(table *goquery.Selection) []models.PlayedLine {
+	cols := detectLineColumns(table)
+	if cols.numbers < 0 {
+		return nil
+	}
+
+	var lines []models.PlayedLine
+	table.Find("tbody tr").Each(func(_ int, row *goquery.Selection) {
+		cells := row.Find("td")
+		cell := func(i int) *goquery.Selection {
+			if i < 0 || i >= cells.Length() {
+				return nil
+			}
+			return cells.Eq(i)
+		}
+
+		numbersCell := cell(cols.numbers)
+		if numbersCell == nil {
+			return
+		}
+		line := models.PlayedLine{Numbers: parseCellNumbers(numbersCell)}
+		if len(line.Numbers) == 0 {
+			return
+		}
+
+		if jc := cell(cols.joker); jc != nil {
+			if nums := parseCellNumbers(jc); len(nums) > 0 {
+				line.Joker = nums[0]
+			}
+		}
+		if cc := cell(cols.category); cc != nil {
+			line.Category = parseCategory(cc.Text())
+		}
+		if pc := cell(cols.prize); pc != nil {
+			if val := strings.TrimSpace(pc.Text()); strings.Contains(val, "RON") {
+				line.Prize = val
+			}
+		}
+
+		lines = append(lines, line)
+	})
+
+	return lines
+}
+
+// parseCellNumbers extracts the numbers in a cell. Numbers rendered as separate
+// elements (balls) are read one per element, otherwise the cell text is tokenized.
+func parseCellNumbers(cell *goquery.Selection) []int {
+	var nums []int
+	cell.Find("span, li, div").Each(func(_ int, el *goquery.Selection) {
+		if el.Children().Length() > 0 {
+			return
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(el.Text())); err == nil {
+			nums = append(nums, n)
+		}
+	})
+	if len(nums) > 0 {
+		return nums
+	}
+
+	for _, tok := range numberPattern.FindAllString(cell.Text(), -1) {
+		if n, err := strconv.Atoi(tok); err == nil {
+			nums = append(nums, n)
+		}
+	}
+	return nums
+}
+
+// parseCategory normalizes a prize category cell ("Cat. III", "Categoria 3") to its numeral
+func parseCategory(text string) string {
+	text = strings.TrimSpace(text)
+	if m := categoryPattern.FindStringSubmatch(text); len(m) == 2 {
+		return strings.ToUpper(m[1])
+	}
+	if text == "-" {
+		return ""
+	}
+	return text
+}
+
+// parseNorocEntries finds Noroc, Super Noroc and Noroc Plus participations.
+// The innermost block holding both a "Noroc" label and a number is used, so the
+// number is read from the same row or list item as its label.
+func parseNorocEntries(doc *goquery.Document) []models.NorocEntry {
+	const blocks = "li, tr, p, div"
+	hasEntry := func(sel *goquery.Selection) bool {
+		text := sel.Text()
+		return strings.Contains(strings.ToLower(text), "noroc") && norocNumberPattern.MatchString(text)
+	}
+
+	var entries []models.NorocEntry
+	seen := make(map[models.Game]bool)
+
+	doc.Find(blocks).Each(func(_ int, el *goquery.Selection) {
+		if !hasEntry(el) || el.Find(blocks).FilterFunction(func(_ int, inner *goquery.Selection) bool {
+			return hasEntry(inner)
+		}).Length() > 0 {
+			return
+		}
+
+		text := el.Text()
+		game := norocGameFromLabel(text)
+		if seen[game] {
+			return
+		}
+		seen[game] = true
+		entries = append(entries, models.NorocEntry{
+			Game:   game,
+			Number: strings.ReplaceAll(norocNumberPattern.FindString(text), " ", ""),
+		})
+	})
+
+	return entries
+}
+
+// norocGameFromLabel identifies which Noroc game a label refers to
+func norocGameFromLabel(label string) models.Game {
+	lower := strings.ToLower(label)
+	switch {
+	case strings.Contains(lower, "super noroc"):
+		return models.GameSuperNoroc
+	case strings.Contains(lower, "noroc plus"):
+		return models.GameNorocPlus
+	default:
+		return models.GameNoroc
+	}
+}
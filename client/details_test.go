@@ -0,0 +1,57 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+func TestGetTicketDetails(t *testing.T) {
+	c := loggedInClient(t, newFakeBilete(t, historyPages))
+
+	for _, id := range []string{"700012", "700010"} {
+		t.Run(id, func(t *testing.T) {
+			details, err := c.GetTicketDetails(t.Context(), "/ticket/details/"+id)
+			if err != nil {
+				t.Fatalf("GetTicketDetails: %v", err)
+			}
+			assertGolden(t, "details_"+id, details)
+		})
+	}
+}
+
+func TestGetTicketPrize(t *testing.T) {
+	c := loggedInClient(t, newFakeBilete(t, historyPages))
+
+	prize, err := c.GetTicketPrize(t.Context(), "/ticket/details/700010")
+	if err != nil {
+		t.Fatalf("GetTicketPrize: %v", err)
+	}
+	if prize != "1.250,40 RON" {
+		t.Errorf("prize = %q, want %q", prize, "1.250,40 RON")
+	}
+
+	if _, err := c.GetTicketPrize(t.Context(), "/ticket/details/404"); err == nil {
+		t.Error("GetTicketPrize: expected an error for a missing ticket")
+	}
+}
+
+func TestFillTicketDetailsFailures(t *testing.T) {
+	c := loggedInClient(t, newFakeBilete(t, historyPages))
+
+	tickets := []models.Ticket{
+		{TicketID: "700010", DetailURL: "/ticket/details/700010"},
+		{TicketID: "404", DetailURL: "/ticket/details/404"},
+		{TicketID: "700012", DetailURL: "/ticket/details/700012"},
+	}
+	err := c.FillTicketDetails(t.Context(), tickets, nil)
+
+	var detailsErr *DetailsError
+	if !errors.As(err, &detailsErr) || detailsErr.Failed != 1 || detailsErr.Total != 3 {
+		t.Fatalf("FillTicketDetails error = %v, want 1 of 3 pages failed", err)
+	}
+	if len(tickets[0].Lines) == 0 || len(tickets[2].Lines) == 0 || len(tickets[1].Lines) != 0 {
+		t.Errorf("played lines: %d, %d, %d; want only the failed ticket without", len(tickets[0].Lines), len(tickets[1].Lines), len(tickets[2].Lines))
+	}
+}
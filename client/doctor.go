@@ -0,0 +1,336 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/rursache/loto-cli/models"
+)
+
+// ProbeStatus is the outcome of one diagnostic check
+type ProbeStatus string
+
+const (
+	ProbeOK   ProbeStatus = "ok"
+	ProbeWarn ProbeStatus = "warn" // unexpected but not necessarily broken, e.g. an empty ticket history
+	ProbeFail ProbeStatus = "fail"
+)
+
+// Probe is one check run by Diagnose against a page
+type Probe struct {
+	Page   string      `json:"page"`  // e.g. "results", "login"
+	Check  string      `json:"check"` // what was looked for, e.g. a selector
+	Status ProbeStatus `json:"status"`
+	Detail string      `json:"detail,omitempty"`
+}
+
+// FetchedPage is a page downloaded by Diagnose, kept for bug reports
+type FetchedPage struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Status int    `json:"status"` // HTTP status, 0 if the request failed
+	// Personal pages belong to the logged-in account: its ticket history and details
+	Personal bool   `json:"personal,omitempty"`
+	Body     []byte `json:"-"`
+}
+
+// Diagnosis is the report of Diagnose
+type Diagnosis struct {
+	Probes []Probe       `json:"probes"`
+	Pages  []FetchedPage `json:"pages"`
+}
+
+// Failed reports whether any check failed
+func (d *Diagnosis) Failed() bool {
+	for _, p := range d.Probes {
+		if p.Status == ProbeFail {
+			return true
+		}
+	}
+	return false
+}
+
+// add records a check
+func (d *Diagnosis) add(page, check string, status ProbeStatus, detail string, args ...any) {
+	if len(args) > 0 {
+		detail = fmt.Sprintf(detail, args...)
+	}
+	d.Probes = append(d.Probes, Probe{Page: page, Check: check, Status: status, Detail: detail})
+}
+
+// expect records a check that passes when ok is true and fails otherwise
+func (d *Diagnosis) expect(page, check string, ok bool, detail string, args ...any) bool {
+	status := ProbeOK
+	if !ok {
+		status = ProbeFail
+	}
+	d.add(page, check, status, detail, args...)
+	return ok
+}
+
+// Diagnose fetches every page the scrapers read and runs the selectors they rely on,
+// reporting which matched. It checks for geo-blocking, CSRF token discovery and the
+// saved session; ticket pages are checked only when the session is valid or the
+// config has credentials to log in with. Failures are reported, not returned.
+func (c *Client) Diagnose(ctx context.Context) *Diagnosis {
+	d := &Diagnosis{}
+
+	c.diagnoseResults(ctx, d)
+	for _, page := range prizePages {
+		c.diagnosePrizes(ctx, d, page.game, page.path)
+	}
+	c.diagnoseLogin(ctx, d)
+	if c.diagnoseSession(ctx, d) {
+		c.diagnoseTickets(ctx, d)
+	}
+
+	return d
+}
+
+// fetchAccountPage is fetchPage for a page of the logged-in account, which is marked personal
+func (c *Client) fetchAccountPage(ctx context.Context, d *Diagnosis, name, rawURL string) *goquery.Document {
+	doc := c.fetchPage(ctx, d, name, rawURL)
+	d.Pages[len(d.Pages)-1].Personal = true
+	return doc
+}
+
+// fetchPage downloads a page for diagnosis without following redirects, recording it
+// and its HTTP status check. It returns nil if the page is not a 200 HTML response.
+func (c *Client) fetchPage(ctx context.Context, d *Diagnosis, name, rawURL string) *goquery.Document {
+	page := FetchedPage{Name: name, URL: rawURL}
+	defer func() { d.Pages = append(d.Pages, page) }()
+
+	req, err := c.newRequest(withoutRedirects(ctx), http.MethodGet, rawURL)
+	if err != nil {
+		d.add(name, "HTTP status", ProbeFail, err.Error())
+		return nil
+	}
+
+	// Not doRequest: the body of a 410 is worth keeping
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		d.add(name, "HTTP status", ProbeFail, "request failed: %v", err)
+		return nil
+	}
+	defer resp.Body.Close()
+
+	page.Status = resp.StatusCode
+	page.Body, err = io.ReadAll(resp.Body)
+	if err != nil {
+		d.add(name, "HTTP status", ProbeFail, "reading body: %v", err)
+		return nil
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusGone:
+		d.add(name, "HTTP status", ProbeFail, "410: geo-blocked, loto.ro requires a Romanian IP address")
+		return nil
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		d.add(name, "HTTP status", ProbeFail, "%d: redirected to %s", resp.StatusCode, resp.Header.Get("Location"))
+		return nil
+	case resp.StatusCode != http.StatusOK:
+		d.add(name, "HTTP status", ProbeFail, "%d", resp.StatusCode)
+		return nil
+	}
+	d.add(name, "HTTP status", ProbeOK, "200")
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page.Body))
+	if err != nil {
+		d.add(name, "HTML", ProbeFail, err.Error())
+		return nil
+	}
+	return doc
+}
+
+// diagnoseResults checks the results row of the homepage
+func (c *Client) diagnoseResults(ctx context.Context, d *Diagnosis) {
+	const page = "results"
+	doc := c.fetchPage(ctx, d, page, c.lotoBase)
+	if doc == nil {
+		return
+	}
+
+	section := doc.Find(resultsSectionSelector).First()
+	if !d.expect(page, resultsSectionSelector, section.Length() > 0, "%d match(es)", section.Length()) {
+		return
+	}
+
+	cols := section.Find(resultsColumnSelector)
+	d.expect(page, resultsColumnSelector, cols.Length() == 3, "%d column(s) (expected 3)", cols.Length())
+
+	cols.Each(func(i int, col *goquery.Selection) {
+		check := fmt.Sprintf("column %d game logo", i+1)
+		if game := identifyGameFromColumn(col); game != "" {
+			d.add(page, check, ProbeOK, string(game))
+		} else {
+			d.add(page, check, ProbeFail, "no img src/data-src naming a known game")
+		}
+	})
+
+	results, _ := parseResults(doc)
+	found := make(map[models.Game]bool)
+	for _, ext := range results {
+		found[ext.Game] = true
+		d.expect(page, ext.Label()+" date", ext.Date != "", ext.Date)
+	}
+	for _, game := range []models.Game{models.GameLoto649, models.GameLoto540, models.GameJoker} {
+		if !found[game] {
+			d.add(page, string(game)+" numbers", ProbeFail, "no 6-column numbers table found")
+		}
+	}
+	d.expect(page, "extractions", len(results) > 0, "%d parsed", len(results))
+}
+
+// diagnosePrizes checks the category tables and jackpot text of a game page
+func (c *Client) diagnosePrizes(ctx context.Context, d *Diagnosis, game models.Game, path string) {
+	page := string(game) + " prizes"
+	doc := c.fetchPage(ctx, d, page, c.lotoBase+path)
+	if doc == nil {
+		return
+	}
+
+	tables := 0
+	doc.Find("table").Each(func(_ int, table *goquery.Selection) {
+		if len(parsePayouts(table)) > 0 {
+			tables++
+		}
+	})
+	d.expect(page, "category tables", tables > 0, "%d table(s) with category and winners columns", tables)
+
+	text := strings.Join(strings.Fields(doc.Find("body").Text()), " ")
+	for _, p := range []struct {
+		check string
+		found bool
+	}{
+		{"draw date", reportDate.MatchString(text)},
+		{"jackpot", jackpotPattern.MatchString(text)},
+		{"next draw date", nextDrawPattern.MatchString(text)},
+	} {
+		// The jackpot and next draw are not always announced
+		if p.found {
+			d.add(page, p.check, ProbeOK, "found")
+		} else {
+			d.add(page, p.check, ProbeWarn, "not found in page text")
+		}
+	}
+}
+
+// diagnoseLogin checks that the login page still carries a CSRF token
+func (c *Client) diagnoseLogin(ctx context.Context, d *Diagnosis) {
+	const page = "login"
+	doc := c.fetchPage(ctx, d, page, c.bileteBase+loginPath)
+	if doc == nil {
+		return
+	}
+	if token, _ := doc.Find(csrfTokenSelector).Attr("content"); token != "" {
+		d.add(page, csrfTokenSelector, ProbeOK, "CSRF token found")
+	} else {
+		d.add(page, csrfTokenSelector, ProbeFail, "CSRF token missing, login will fail")
+	}
+}
+
+// diagnoseSession checks the saved cookies and logs in if they are not valid and
+// credentials are configured. It reports whether the client ends up authenticated.
+func (c *Client) diagnoseSession(ctx context.Context, d *Diagnosis) bool {
+	const page = "session"
+
+	path, err := getCookiesPath()
+	if err != nil {
+		d.add(page, "saved cookies", ProbeFail, err.Error())
+		return false
+	}
+	if _, err := os.Stat(path); err != nil {
+		d.add(page, "saved cookies", ProbeWarn, "none saved at %s", path)
+	} else if err := c.LoadCookies(); err != nil {
+		d.add(page, "saved cookies", ProbeFail, "loading %s: %v", path, err)
+	} else if c.IsAuthenticated(ctx) {
+		d.add(page, "saved cookies", ProbeOK, "session is valid")
+		return true
+	} else {
+		d.add(page, "saved cookies", ProbeWarn, "session expired")
+	}
+
+	if err := c.Config.RequireCredentials(); err != nil {
+		d.add(page, "login", ProbeWarn, "no credentials configured, skipping ticket pages")
+		return false
+	}
+	if err := c.Login(ctx); err != nil {
+		d.add(page, "login", ProbeFail, err.Error())
+		return false
+	}
+	d.add(page, "login", ProbeOK, "logged in as %s", c.Config.Email)
+	return true
+}
+
+// diagnoseTickets checks the first ticket history page and a ticket detail page
+func (c *Client) diagnoseTickets(ctx context.Context, d *Diagnosis) {
+	const page = "tickets"
+	doc := c.fetchAccountPage(ctx, d, page, c.bileteBase+authCheckPath)
+	if doc == nil {
+		return
+	}
+
+	title := doc.Find("title").First().Text()
+	d.expect(page, "title", strings.Contains(title, authenticatedTitle), "%q", strings.TrimSpace(title))
+
+	cards := doc.Find(ticketCardSelector)
+	if cards.Length() == 0 {
+		d.add(page, ticketCardSelector, ProbeWarn, "no ticket cards (empty history or changed markup)")
+		return
+	}
+	d.add(page, ticketCardSelector, ProbeOK, "%d card(s)", cards.Length())
+
+	total := parseTotalCount(doc)
+	d.expect(page, ticketCountSelector, total > 0, "%d ticket(s) in total", total)
+
+	// Check every field of the first card, then follow the detail link of a won ticket if any
+	card := cards.First()
+	d.expect(page, ticketItemSelector, card.Find(ticketItemSelector).Length() > 0, "%d item(s)", card.Find(ticketItemSelector).Length())
+
+	t := parseTicketCard(card)
+	for _, f := range []struct {
+		check string
+		ok    bool
+		value string
+	}{
+		{"game logo", t.Game != "", string(t.Game)},
+		{"span.price", !t.PriceAmount.IsZero(), t.Price},
+		{"ticket ID", t.TicketID != "", t.TicketID},
+		{"draw date", !t.DrawTime.IsZero(), t.DrawDate},
+		{"span.badge", strings.TrimSpace(card.Find("span.badge").Text()) != "", t.Status.Key()},
+		{ticketDetailLinkSelector, t.DetailURL != "", t.DetailURL},
+		{ticketPlayedSelector, !t.PlayedTime.IsZero(), t.PlayedAt},
+	} {
+		d.expect(page, f.check, f.ok, "%q", f.value)
+	}
+
+	detailURL := t.DetailURL
+	won := false
+	cards.EachWithBreak(func(_ int, card *goquery.Selection) bool {
+		if ct := parseTicketCard(card); ct.Status == models.StatusWon && ct.DetailURL != "" {
+			detailURL, won = ct.DetailURL, true
+			return false
+		}
+		return true
+	})
+	if detailURL == "" {
+		return
+	}
+
+	const detailPage = "ticket details"
+	detailDoc := c.fetchAccountPage(ctx, d, detailPage, c.resolveBilete(detailURL))
+	if detailDoc == nil {
+		return
+	}
+	details := parseTicketDetails(detailDoc)
+	d.expect(detailPage, "played lines", len(details.Lines) > 0, "%d line(s)", len(details.Lines))
+	if won {
+		d.expect(detailPage, "total prize", details.Prize != "", "%q", details.Prize)
+	}
+}
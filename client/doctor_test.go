@@ -0,0 +1,86 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+func TestDiagnose(t *testing.T) {
+	loto := pages(t, map[string]string{
+		"/":           "loto/results_standard.html",
+		"/loto-6-49/": "loto/prizes_649.html",
+	})
+	c := newTestClient(t, loto, newFakeBilete(t, historyPages))
+
+	d := c.Diagnose(t.Context())
+
+	failed := make(map[string]bool)
+	for _, p := range d.Probes {
+		if p.Status == ProbeFail {
+			failed[p.Page] = true
+		}
+	}
+	// Only the game pages without a fixture fail
+	for _, page := range []string{"results", "Loto 6/49 prizes", "login", "session", "tickets", "ticket details"} {
+		if failed[page] {
+			t.Errorf("checks of %q failed: %+v", page, d.Probes)
+		}
+	}
+	if !failed[string(models.GameLoto540)+" prizes"] {
+		t.Error("expected the missing Loto 5/40 page to fail")
+	}
+
+	// Every fetched page is kept for the dump
+	if len(d.Pages) != 7 {
+		t.Errorf("got %d fetched pages, want 7", len(d.Pages))
+	}
+	for _, p := range d.Pages {
+		if p.Status == 200 && len(p.Body) == 0 {
+			t.Errorf("page %s has no body", p.Name)
+		}
+		// Only the pages of the logged-in account are personal
+		if personal := p.Name == "tickets" || p.Name == "ticket details"; p.Personal != personal {
+			t.Errorf("page %s personal = %v, want %v", p.Name, p.Personal, personal)
+		}
+	}
+}
+
+func TestDiagnoseChangedMarkup(t *testing.T) {
+	c := newTestClient(t, pages(t, map[string]string{"/": "loto/results_redesigned.html"}), nil)
+
+	d := c.Diagnose(t.Context())
+	if !d.Failed() {
+		t.Fatal("Failed() = false, want true")
+	}
+
+	var found bool
+	for _, p := range d.Probes {
+		if p.Page == "results" && p.Check == resultsSectionSelector {
+			found = p.Status == ProbeFail
+		}
+	}
+	if !found {
+		t.Errorf("expected a failed %s check: %+v", resultsSectionSelector, d.Probes)
+	}
+}
+
+func TestDiagnoseGeoBlocked(t *testing.T) {
+	blocked := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+		w.Write([]byte("Access denied"))
+	})
+	c := newTestClient(t, blocked, blocked)
+
+	d := c.Diagnose(t.Context())
+	for _, p := range d.Probes {
+		if p.Check == "HTTP status" && (p.Status != ProbeFail || !strings.Contains(p.Detail, "geo-blocked")) {
+			t.Errorf("%s: got %s %q, want a geo-blocked failure", p.Page, p.Status, p.Detail)
+		}
+	}
+	if len(d.Pages) == 0 || string(d.Pages[0].Body) != "Access denied" {
+		t.Error("the body of the 410 response should be kept")
+	}
+}
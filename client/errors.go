@@ -0,0 +1,57 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the client. They are usually wrapped with context,
+// so compare them with errors.Is.
+var (
+	// ErrGeoBlocked is returned when loto.ro answers HTTP 410, as it does for non-Romanian IP addresses
+	ErrGeoBlocked = errors.New("loto.ro requires a Romanian IP address. Please connect from Romania or use a VPN")
+
+	// ErrSessionExpired is returned when bilete.loto.ro redirects a page to the login form
+	ErrSessionExpired = errors.New("session expired, please log in again")
+
+	// ErrInvalidCredentials is returned when the login form rejects the email or password
+	ErrInvalidCredentials = errors.New("invalid credentials: check the email and password in the config file")
+
+	// ErrRateLimited is returned when loto.ro answers HTTP 429
+	ErrRateLimited = errors.New("too many requests, loto.ro is rate limiting this client")
+)
+
+// ParseError is returned when a page loads but cannot be scraped, usually because
+// loto.ro changed its markup. Use errors.As to get the page and selector.
+type ParseError struct {
+	Page     string // page being scraped, e.g. "results", "login"
+	Selector string // what was not found, empty if the HTML itself could not be parsed
+	Err      error  // underlying error, if any
+}
+
+func (e *ParseError) Error() string {
+	if e.Selector == "" {
+		return fmt.Sprintf("failed to parse %s page: %v", e.Page, e.Err)
+	}
+	return fmt.Sprintf("%s not found on %s page", e.Selector, e.Page)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// DetailsError is returned with the tickets when some of their detail pages failed
+// to load. Those tickets lack their played lines, Noroc numbers and prize.
+type DetailsError struct {
+	Failed int   // detail pages that failed to load
+	Total  int   // detail pages fetched
+	Err    error // first failure
+}
+
+func (e *DetailsError) Error() string {
+	return fmt.Sprintf("%d of %d ticket detail pages failed to load: %v", e.Failed, e.Total, e.Err)
+}
+
+func (e *DetailsError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// Progress stages reported while fetching the ticket history
+const (
+	StagePages   = "pages"   // ticket history pages
+	StageDetails = "details" // ticket detail pages
+)
+
+// ProgressFunc is called after each page is fetched with the number of pages of the
+// stage fetched so far and in total. Calls are never concurrent; nil disables reporting.
+type ProgressFunc func(stage string, done, total int)
+
+// report calls progress if it is set
+func (progress ProgressFunc) report(stage string, done, total int) {
+	if progress != nil {
+		progress(stage, done, total)
+	}
+}
+
+// forEach calls fn for every index in [0, n) on up to c.concurrency goroutines.
+// The first error cancels the remaining calls and is returned. After each successful
+// call, progress is reported with the count of calls done so far.
+func (c *Client) forEach(ctx context.Context, n int, stage string, progress ProgressFunc, fn func(ctx context.Context, i int) error) error {
+	if n == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	indexes := make(chan int)
+	go func() {
+		defer close(indexes)
+		for i := 0; i < n; i++ {
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		done     int
+		firstErr error
+	)
+	for w := 0; w < min(c.concurrency, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				err := fn(ctx, i)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else if firstErr == nil {
+					done++
+					progress.report(stage, done, n)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEach(t *testing.T) {
+	c := &Client{concurrency: 3}
+
+	var running, peak atomic.Int32
+	results := make([]int, 20)
+	err := c.forEach(t.Context(), len(results), StagePages, nil, func(ctx context.Context, i int) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(time.Millisecond)
+		results[i] = i * i
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("forEach: %v", err)
+	}
+	if peak.Load() > 3 {
+		t.Errorf("%d calls ran at once, want at most 3", peak.Load())
+	}
+	for i, r := range results {
+		if r != i*i {
+			t.Fatalf("results[%d] = %d, want %d", i, r, i*i)
+		}
+	}
+}
+
+func TestForEachStopsOnError(t *testing.T) {
+	c := &Client{concurrency: 2}
+	boom := errors.New("boom")
+
+	var calls atomic.Int32
+	err := c.forEach(t.Context(), 100, StagePages, nil, func(ctx context.Context, i int) error {
+		calls.Add(1)
+		if i == 3 {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("forEach error = %v, want boom", err)
+	}
+	if n := calls.Load(); n >= 100 {
+		t.Errorf("%d calls made, want the rest cancelled", n)
+	}
+}
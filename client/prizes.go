@@ -0,0 +1,244 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/rursache/loto-cli/models"
+)
+
+// prizePages are the loto.ro pages publishing the prize breakdown of each game's
+// latest draw. Noroc games are reported on the page of the game they are played with.
+var prizePages = []struct {
+	game models.Game
+	path string
+}{
+	{models.GameLoto649, "/loto-6-49/"},
+	{models.GameLoto540, "/loto-5-40/"},
+	{models.GameJoker, "/joker/"},
+}
+
+var (
+	romanPattern    = regexp.MustCompile(`^[IVX]+\b`)
+	reportDate      = regexp.MustCompile(`(?i)extrager\S*\s+(?:din\s+)?(?:data\s+(?:de\s+)?)?(\d{2}[.\-/]\d{2}[.\-/]\d{4})`)
+	nextDrawPattern = regexp.MustCompile(`(?i)urm[aă]to\S*\s+extrager\S*\D{0,40}?(\d{2}[.\-/]\d{2}[.\-/]\d{4})`)
+	jackpotPattern  = regexp.MustCompile(`(?i)(?:report|estimat)\S*\s+(?:la\s+)?(?:categoria|cat\.?)\s*I\b.{0,80}?(\d{1,3}(?:\.\d{3})+(?:,\d{2})?|\d+(?:,\d{2})?)\s*(lei|ron|euro|eur)\b`)
+)
+
+// GetPrizeReports scrapes the prize breakdown of the latest draw of every game,
+// together with the Noroc game played alongside it and the next draw's jackpot.
+// Games whose page fails to load are skipped; an error is returned only if all fail.
+func (c *Client) GetPrizeReports(ctx context.Context) ([]models.PrizeReport, error) {
+	var reports []models.PrizeReport
+	var lastErr error
+
+	for _, page := range prizePages {
+		r, err := c.GetPrizeReport(ctx, page.game)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		reports = append(reports, r...)
+	}
+
+	if len(reports) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return reports, nil
+}
+
+// GetPrizeReport scrapes the prize breakdown of a game's latest draw.
+// The game must be Loto 6/49, Loto 5/40 or Joker; the report of its Noroc game
+// is returned after it when the page has one.
+//
+// The game page holds one table per game with a header row, e.g.
+//
+//	"Categoria", "Nr. variante câștigătoare", "Valoarea unui câștig", "Report"
+//
+// followed by one row per category. The jackpot is the category I amount
+// announced for the next draw ("report" or "câștig estimat").
+func (c *Client) GetPrizeReport(ctx context.Context, game models.Game) ([]models.PrizeReport, error) {
+	path := ""
+	for _, page := range prizePages {
+		if page.game == game {
+			path = page.path
+		}
+	}
+	if path == "" {
+		return nil, fmt.Errorf("no prize report page for %s", game)
+	}
+
+	req, err := c.newRequest(ctx, "GET", c.lotoBase+path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s prizes: %w", game, err)
+	}
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s prizes: %w", game, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("unexpected status code %d when fetching %s prizes", resp.StatusCode, game)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, &ParseError{Page: string(game) + " prizes", Err: err}
+	}
+
+	reports := parsePrizeReports(doc, game)
+	if len(reports) == 0 {
+		return nil, &ParseError{Page: string(game) + " prizes", Selector: "category table"}
+	}
+	return reports, nil
+}
+
+// parsePrizeReports extracts the category tables of a game page. The first table
+// belongs to the game, the second to its Noroc game.
+func parsePrizeReports(doc *goquery.Document, game models.Game) []models.PrizeReport {
+	text := strings.Join(strings.Fields(doc.Find("body").Text()), " ")
+
+	var date, nextDraw, jackpot string
+	if m := reportDate.FindStringSubmatch(text); m != nil {
+		date = m[1]
+	}
+	if m := nextDrawPattern.FindStringSubmatch(text); m != nil {
+		nextDraw = m[1]
+	}
+	if m := jackpotPattern.FindStringSubmatch(text); m != nil {
+		jackpot = m[1] + " " + strings.ToLower(m[2])
+	}
+
+	games := []models.Game{game, models.NorocGameFor(game)}
+
+	var reports []models.PrizeReport
+	doc.Find("table").Each(func(_ int, table *goquery.Selection) {
+		if len(reports) == len(games) || games[len(reports)] == "" {
+			return
+		}
+		categories := parsePayouts(table)
+		if len(categories) == 0 {
+			return
+		}
+		r := models.PrizeReport{
+			Game:       games[len(reports)],
+			Date:       date,
+			Categories: categories,
+		}
+		if len(reports) == 0 {
+			r.Jackpot = jackpot
+			r.NextDraw = nextDraw
+		}
+		reports = append(reports, r)
+	})
+
+	return reports
+}
+
+// payoutColumns maps the role of each column in a category table to its index (-1 if absent)
+type payoutColumns struct {
+	category, winners, prize, rollover int
+}
+
+// detectPayoutColumns identifies the category table columns from the header labels
+func detectPayoutColumns(table *goquery.Selection) payoutColumns {
+	cols := payoutColumns{category: -1, winners: -1, prize: -1, rollover: -1}
+	header := table.Find("thead th, thead td")
+	if header.Length() == 0 {
+		header = table.Find("tr").First().Find("th, td")
+	}
+	header.Each(func(i int, th *goquery.Selection) {
+		label := strings.ToLower(strings.TrimSpace(th.Text()))
+		switch {
+		case strings.Contains(label, "categ"):
+			cols.category = i
+		case strings.Contains(label, "câștigătoare") || strings.Contains(label, "castigatoare") ||
+			strings.Contains(label, "câștigători") || strings.Contains(label, "castigatori") ||
+			strings.Contains(label, "număr") || strings.Contains(label, "numar") || strings.Contains(label, "nr."):
+			cols.winners = i
+		case strings.Contains(label, "report"):
+			cols.rollover = i
+		case strings.Contains(label, "valoare") || strings.Contains(label, "câștig") ||
+			strings.Contains(label, "castig") || strings.Contains(label, "premiu"):
+			cols.prize = i
+		}
+	})
+	return cols
+}
+
+// parsePayouts extracts one CategoryPayout per category row of a table.
+// Tables without category and winners columns yield nothing.
+func parsePayouts(table *goquery.Selection) []models.CategoryPayout {
+	cols := detectPayoutColumns(table)
+	if cols.category < 0 || cols.winners < 0 {
+		return nil
+	}
+
+	var payouts []models.CategoryPayout
+	table.Find("tr").Each(func(_ int, row *goquery.Selection) {
+		cells := row.Find("td")
+		cell := func(i int) string {
+			if i < 0 || i >= cells.Length() {
+				return ""
+			}
+			return strings.TrimSpace(cells.Eq(i).Text())
+		}
+
+		category := parsePayoutCategory(cell(cols.category))
+		if category == "" {
+			return
+		}
+
+		payouts = append(payouts, models.CategoryPayout{
+			Category: category,
+			Winners:  parseWinners(cell(cols.winners)),
+			Prize:    amountOrEmpty(cell(cols.prize)),
+			Rollover: amountOrEmpty(cell(cols.rollover)),
+		})
+	})
+
+	return payouts
+}
+
+// parsePayoutCategory reads the roman numeral of a category cell such as
+// "I", "Categoria a II-a" or "III (4/6)"
+func parsePayoutCategory(text string) string {
+	if m := categoryPattern.FindStringSubmatch(text); len(m) == 2 {
+		return strings.ToUpper(m[1])
+	}
+	text = strings.TrimSpace(strings.TrimPrefix(strings.ToLower(text), "categoria"))
+	text = strings.TrimPrefix(text, "a ")
+	return romanPattern.FindString(strings.ToUpper(text))
+}
+
+// parseWinners reads a winners count, ignoring thousands separators ("1.234")
+func parseWinners(text string) int {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		if r == '.' || r == ' ' {
+			return -1
+		}
+		return 'x'
+	}, text)
+	if i := strings.IndexByte(digits, 'x'); i >= 0 {
+		digits = digits[:i]
+	}
+	n, _ := strconv.Atoi(digits)
+	return n
+}
+
+// amountOrEmpty returns an amount cell, or "" for placeholders like "-"
+func amountOrEmpty(text string) string {
+	if strings.IndexFunc(text, func(r rune) bool { return r >= '1' && r <= '9' }) < 0 {
+		return ""
+	}
+	return text
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+func TestGetPrizeReport(t *testing.T) {
+	c := newTestClient(t, pages(t, map[string]string{"/loto-6-49/": "loto/prizes_649.html"}), nil)
+
+	reports, err := c.GetPrizeReport(t.Context(), models.GameLoto649)
+	if err != nil {
+		t.Fatalf("GetPrizeReport: %v", err)
+	}
+	assertGolden(t, "prizes_649", reports)
+}
+
+func TestGetPrizeReportsPartial(t *testing.T) {
+	// Only the 6/49 page is served; the other games are skipped
+	c := newTestClient(t, pages(t, map[string]string{"/loto-6-49/": "loto/prizes_649.html"}), nil)
+
+	reports, err := c.GetPrizeReports(t.Context())
+	if err != nil {
+		t.Fatalf("GetPrizeReports: %v", err)
+	}
+	if len(reports) != 2 || reports[0].Game != models.GameLoto649 || reports[1].Game != models.GameNoroc {
+		t.Errorf("got %d report(s), want Loto 6/49 and Noroc", len(reports))
+	}
+}
+
+func TestGetPrizeReportUnknownGame(t *testing.T) {
+	c := newTestClient(t, nil, nil)
+
+	if _, err := c.GetPrizeReport(t.Context(), models.GameNoroc); err == nil {
+		t.Error("GetPrizeReport(Noroc): expected an error, Noroc is reported with Loto 6/49")
+	}
+}
@@ -1,32 +1,42 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/rursache/loto-cli/models"
+	"golang.org/x/net/html"
 )
 
 // resultsSectionClass identifies the results row on the loto.ro homepage.
 const resultsSectionClass = "vc_custom_1643109784313"
 
+// Selectors the results scraper relies on
+const (
+	resultsSectionSelector = "div[class*='" + resultsSectionClass + "']"
+	resultsColumnSelector  = "div.vc_col-sm-4"
+)
+
 // GetResults scrapes the latest lottery extraction results from the loto.ro main page.
 //
 // The page structure has 3 columns (vc_col-sm-4) inside the results row:
 //
 //	Column 1: Loto 6/49 + Noroc     (logo: Loto_6_49__noroc.png)
 //	Column 2: Loto 5/40 + Super Noroc (logo: Loto_5_40__super_noroc.png)
-//	Column 3: Joker                  (logo: joker__noroc_plus.png)
+//	Column 3: Joker + Noroc Plus     (logo: joker__noroc_plus.png)
 //
 // Each column contains Ninja Tables (footable_*):
-//   - First visible 6-col table: main draw numbers
+//   - First 6-col table: main draw numbers
 //   - 1-col table with a date (DD-MM-YYYY): draw date
-//   - 1-col table with space-separated digits: Noroc/Super Noroc number
-//   - Hidden tables (parent has "ascuns" class): second draw (ignored)
-func (c *Client) GetResults() ([]models.Extraction, error) {
-	req, err := c.newRequest("GET", baseLotoURL)
+//   - 1-col table with space-separated digits: Noroc/Super Noroc/Noroc Plus number
+//   - Hidden tables (parent has "ascuns" class): additional draws, one per hidden
+//     section, returned with Extraction.Draw set to 1, 2...
+func (c *Client) GetResults(ctx context.Context) ([]models.Extraction, error) {
+	req, err := c.newRequest(ctx, "GET", c.lotoBase)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request for results: %w", err)
 	}
@@ -43,99 +53,154 @@ func (c *Client) GetResults() ([]models.Extraction, error) {
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse results HTML: %w", err)
+		return nil, &ParseError{Page: "results", Err: err}
 	}
 
-	resultsRow := doc.Find("div[class*='" + resultsSectionClass + "']").First()
+	return parseResults(doc)
+}
+
+// parseResults extracts every draw from the results row of the homepage
+func parseResults(doc *goquery.Document) ([]models.Extraction, error) {
+	resultsRow := doc.Find(resultsSectionSelector).First()
 	if resultsRow.Length() == 0 {
-		return nil, fmt.Errorf("results section not found on page")
+		return nil, &ParseError{Page: "results", Selector: resultsSectionSelector}
 	}
 
 	var extractions []models.Extraction
 
 	// Iterate over the 3 columns
-	resultsRow.Find("div.vc_col-sm-4").Each(func(colIdx int, col *goquery.Selection) {
+	resultsRow.Find(resultsColumnSelector).Each(func(colIdx int, col *goquery.Selection) {
 		game := identifyGameFromColumn(col)
 		if game == "" {
 			return
 		}
 
-		var numbers []int
-		var date string
-		var norocNumber string
+		// Visible tables form the main draw, each hidden section an additional draw
+		main := &drawSection{}
+		sections := []*drawSection{main}
+		hidden := make(map[*html.Node]*drawSection)
 
-		// Find all visible tables in this column (skip hidden "ascuns" ones)
 		col.Find("table").Each(func(_ int, table *goquery.Selection) {
-			if isHidden(table) {
-				return
+			sec := main
+			if node := hiddenAncestor(table); node != nil {
+				if sec = hidden[node]; sec == nil {
+					sec = &drawSection{}
+					hidden[node] = sec
+					sections = append(sections, sec)
+				}
 			}
+			sec.add(extractCells(table))
+		})
 
-			cells := extractCells(table)
-			if len(cells) == 0 {
-				return
-			}
+		mainExts := main.extractions(game, main.date, 0)
+		extractions = append(extractions, mainExts...)
 
-			// Single-cell table: either a date or a Noroc number
-			if len(cells) == 1 {
-				val := strings.TrimSpace(cells[0])
-				if isDate(val) {
-					if date == "" {
-						date = val
-					}
-				} else if isSpacedDigits(val) {
-					norocNumber = strings.ReplaceAll(val, " ", "")
-				}
-				return
+		draw := 0
+		for _, sec := range sections[1:] {
+			date := sec.date
+			if date == "" {
+				date = main.date
 			}
-
-			// Multi-cell table: draw numbers (take only the first visible one)
-			if len(numbers) == 0 {
-				for _, cell := range cells {
-					if n, err := strconv.Atoi(strings.TrimSpace(cell)); err == nil {
-						numbers = append(numbers, n)
-					}
-				}
+			exts := sec.extractions(game, date, draw+1)
+			// Some layouts repeat the main draw in a hidden block
+			if len(exts) == 0 || sameDraw(exts[0], mainExts) {
+				continue
 			}
-		})
+			draw++
+			extractions = append(extractions, exts...)
+		}
+	})
 
-		// Build the main game extraction
-		if len(numbers) > 0 {
-			ext := models.Extraction{
-				Game:    game,
-				Date:    date,
-				Numbers: numbers,
+	return extractions, nil
+}
+
+// drawSection collects the tables of one draw in a results column
+type drawSection struct {
+	numbers []int
+	date    string
+	noroc   string
+}
+
+// add records the contents of a table
+func (s *drawSection) add(cells []string) {
+	if len(cells) == 0 {
+		return
+	}
+
+	// Single-cell table: either a date or a Noroc number
+	if len(cells) == 1 {
+		val := strings.TrimSpace(cells[0])
+		if isDate(val) {
+			if s.date == "" {
+				s.date = val
 			}
+		} else if isSpacedDigits(val) {
+			s.noroc = strings.ReplaceAll(val, " ", "")
+		}
+		return
+	}
 
-			// For Joker: last number in the 6-cell row is the Joker bonus
-			if game == models.GameJoker && len(numbers) == 6 {
-				ext.Numbers = numbers[:5]
-				ext.Bonus = numbers[5:]
+	// Multi-cell table: draw numbers (take only the first one)
+	if len(s.numbers) == 0 {
+		for _, cell := range cells {
+			if n, err := strconv.Atoi(strings.TrimSpace(cell)); err == nil {
+				s.numbers = append(s.numbers, n)
 			}
+		}
+	}
+}
+
+// extractions builds the main game and Noroc extractions of the section
+func (s *drawSection) extractions(game models.Game, date string, draw int) []models.Extraction {
+	var exts []models.Extraction
 
-			extractions = append(extractions, ext)
+	if len(s.numbers) > 0 {
+		ext := models.Extraction{
+			Game:    game,
+			Date:    date,
+			Draw:    draw,
+			Numbers: s.numbers,
 		}
 
-		// Build the Noroc/Super Noroc extraction from the spaced-digit table
-		if norocNumber != "" {
-			norocGame := norocGameForColumn(game)
-			if norocGame != "" {
-				// Convert each digit to an int for the Numbers field
-				var digits []int
-				for _, ch := range norocNumber {
-					if d, err := strconv.Atoi(string(ch)); err == nil {
-						digits = append(digits, d)
-					}
+		// For Joker: last number in the 6-cell row is the Joker bonus
+		if game == models.GameJoker && len(s.numbers) == 6 {
+			ext.Numbers = s.numbers[:5]
+			ext.Bonus = s.numbers[5:]
+		}
+
+		exts = append(exts, ext)
+	}
+
+	// Build the Noroc extraction from the spaced-digit table
+	if s.noroc != "" {
+		if norocGame := models.NorocGameFor(game); norocGame != "" {
+			// Convert each digit to an int for the Numbers field
+			var digits []int
+			for _, ch := range s.noroc {
+				if d, err := strconv.Atoi(string(ch)); err == nil {
+					digits = append(digits, d)
 				}
-				extractions = append(extractions, models.Extraction{
-					Game:    norocGame,
-					Date:    date,
-					Numbers: digits,
-				})
 			}
+			exts = append(exts, models.Extraction{
+				Game:    norocGame,
+				Date:    date,
+				Draw:    draw,
+				Numbers: digits,
+			})
 		}
-	})
+	}
 
-	return extractions, nil
+	return exts
+}
+
+// sameDraw reports whether ext repeats the draw of the same game in exts
+func sameDraw(ext models.Extraction, exts []models.Extraction) bool {
+	for _, e := range exts {
+		if e.Game == ext.Game && e.Date == ext.Date && slices.Equal(e.Numbers, ext.Numbers) && slices.Equal(e.Bonus, ext.Bonus) {
+			return true
+		}
+	}
+	return false
 }
 
 // identifyGameFromColumn determines the game type from the logo image in the column.
@@ -162,26 +227,17 @@ func identifyGameFromColumn(col *goquery.Selection) models.Game {
 	return game
 }
 
-// norocGameForColumn maps a main game to its associated Noroc game.
-func norocGameForColumn(mainGame models.Game) models.Game {
-	switch mainGame {
-	case models.GameLoto649:
-		return models.GameNoroc
-	case models.GameLoto540:
-		return models.GameSuperNoroc
-	default:
-		return ""
-	}
-}
-
-// isHidden checks if a table (or its ancestor) has the "ascuns" class, meaning it's hidden.
-func isHidden(table *goquery.Selection) bool {
-	hidden := false
-	table.Parents().Each(func(_ int, parent *goquery.Selection) {
+// hiddenAncestor returns the nearest ancestor of a table with the "ascuns" class,
+// meaning it's hidden, or nil if the table is visible.
+func hiddenAncestor(table *goquery.Selection) *html.Node {
+	var hidden *html.Node
+	table.Parents().EachWithBreak(func(_ int, parent *goquery.Selection) bool {
 		class, _ := parent.Attr("class")
 		if strings.Contains(class, "ascuns") {
-			hidden = true
+			hidden = parent.Get(0)
+			return false
 		}
+		return true
 	})
 	return hidden
 }
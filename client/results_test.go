@@ -0,0 +1,47 @@
+package client
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetResults(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+	}{
+		{"standard", "loto/results_standard.html"},       // all three columns, logos in src
+		{"lazy", "loto/results_lazy.html"},               // lazy-loaded logos in data-src, old logo names, DD.MM.YYYY dates
+		{"second_draw", "loto/results_second_draw.html"}, // additional draw and a repeated main draw in hidden sections
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, pages(t, map[string]string{"/": tt.fixture}), nil)
+
+			results, err := c.GetResults(t.Context())
+			if err != nil {
+				t.Fatalf("GetResults: %v", err)
+			}
+			assertGolden(t, "results_"+tt.name, results)
+		})
+	}
+}
+
+func TestGetResultsSectionMissing(t *testing.T) {
+	c := newTestClient(t, pages(t, map[string]string{"/": "loto/results_redesigned.html"}), nil)
+
+	_, err := c.GetResults(t.Context())
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Page != "results" || perr.Selector != resultsSectionSelector {
+		t.Fatalf("GetResults error = %v, want a ParseError for the results section", err)
+	}
+}
+
+func TestGetResultsStatus(t *testing.T) {
+	c := newTestClient(t, nil, nil)
+
+	if _, err := c.GetResults(t.Context()); err == nil {
+		t.Fatal("GetResults: expected an error for HTTP 404")
+	}
+}
@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rursache/loto-cli/config"
+)
+
+// retryTransport wraps the HTTP transport of a Client. Every attempt waits for the
+// rate limiter and gets its own timeout; idempotent requests answered with HTTP 429
+// or 5xx, or failing with a transient network error, are retried with exponential
+// backoff and jitter, waiting for Retry-After when the server sends it. Other
+// requests, such as the login form, are never sent twice since the server may
+// already have acted on them.
+type retryTransport struct {
+	base    http.RoundTripper
+	limiter *limiter
+
+	timeout  time.Duration // per attempt, including reading the body
+	retries  int
+	delay    time.Duration // before the first retry, doubled for each next one
+	maxDelay time.Duration // longest wait between attempts
+}
+
+func newRetryTransport(base http.RoundTripper, cfg *config.Config) *retryTransport {
+	return &retryTransport{
+		base:     base,
+		limiter:  newLimiter(cfg.RequestRate()),
+		timeout:  cfg.RequestTimeoutDuration(),
+		retries:  cfg.RetryLimit(),
+		delay:    cfg.RetryDelayDuration(),
+		maxDelay: cfg.RetryMaxDelayDuration(),
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		resp, err := t.attempt(req)
+
+		wait, retry := t.backoff(ctx, attempt, resp, err)
+		if !retry || !idempotent(req) || !rewindable(req) {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // lets the connection be reused
+			resp.Body.Close()
+		}
+
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt makes a single attempt of req within the per-attempt timeout.
+// The timeout keeps running while the response body is read.
+func (t *retryTransport) attempt(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) && req.Context().Err() == nil {
+			err = &timeoutError{timeout: t.timeout}
+		}
+		return nil, err
+	}
+	resp.Body = &cancelBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff decides whether a failed attempt is retried and how long to wait before
+func (t *retryTransport) backoff(ctx context.Context, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if attempt >= t.retries || ctx.Err() != nil {
+		return 0, false
+	}
+	if err != nil {
+		return t.jitter(attempt), transient(err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return 0, false
+	}
+
+	if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+		// Waiting longer than allowed is not worth it: give up with the response
+		return wait, wait <= t.maxDelay
+	}
+	return t.jitter(attempt), true
+}
+
+// jitter returns the exponential backoff delay of an attempt, randomized between
+// half and all of it so concurrent requests don't retry in lockstep
+func (t *retryTransport) jitter(attempt int) time.Duration {
+	d := t.maxDelay
+	if attempt < 30 {
+		d = min(t.delay<<attempt, t.maxDelay)
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// transient reports whether a request error is worth retrying: timeouts, dropped
+// connections and temporary DNS failures, but not e.g. an unknown host
+func transient(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTimeout || dnsErr.IsTemporary
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// idempotent reports whether req may be sent again: GET and HEAD requests, and
+// others marked with an Idempotency-Key or X-Idempotency-Key header, as net/http does
+func idempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead:
+		return true
+	}
+	_, key := req.Header["Idempotency-Key"]
+	_, xKey := req.Header["X-Idempotency-Key"]
+	return key || xKey
+}
+
+// rewindable reports whether the body of req can be sent again
+func rewindable(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// rewind returns a copy of req with a fresh body for the next attempt
+func rewind(req *http.Request) (*http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Body = body
+	return req, nil
+}
+
+// sleep waits for d, or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// timeoutError is returned when an attempt gets no response within the request timeout.
+// It is a net.Error, like the timeouts of http.Client.
+type timeoutError struct {
+	timeout time.Duration
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf("no response within %v", e.timeout)
+}
+
+func (e *timeoutError) Timeout() bool   { return true }
+func (e *timeoutError) Temporary() bool { return true }
+func (e *timeoutError) Unwrap() error   { return context.DeadlineExceeded }
+
+// cancelBody releases the timeout of an attempt once its response body is closed
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// limiter spaces out request starts so that at most rate requests per second are
+// made, across every goroutine using the client
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time // earliest start of the next request
+}
+
+func newLimiter(rate float64) *limiter {
+	return &limiter{interval: time.Duration(float64(time.Second) / rate)}
+}
+
+// wait blocks until the next request may start, or ctx is done
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay := time.Until(start); delay > 0 {
+		return sleep(ctx, delay)
+	}
+	return nil
+}
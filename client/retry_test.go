@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/rursache/loto-cli/config"
+)
+
+// flaky answers the first n requests with status, then serves the results page
+func flaky(t *testing.T, n int32, status int, header http.Header) (http.Handler, *atomic.Int32) {
+	var attempts atomic.Int32
+	results := pages(t, map[string]string{"/": "loto/results_standard.html"})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= n {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		results.ServeHTTP(w, r)
+	}), &attempts
+}
+
+func TestRetryServerErrors(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable} {
+		h, attempts := flaky(t, 2, status, nil)
+		c := newTestClient(t, h, nil)
+
+		if _, err := c.GetResults(t.Context()); err != nil {
+			t.Fatalf("HTTP %d: GetResults: %v", status, err)
+		}
+		if n := attempts.Load(); n != 3 {
+			t.Errorf("HTTP %d: %d attempts, want 3", status, n)
+		}
+	}
+}
+
+func TestRetryNotForClientErrors(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusGone} {
+		h, attempts := flaky(t, 1, status, nil)
+		c := newTestClient(t, h, nil)
+
+		if _, err := c.GetResults(t.Context()); err == nil {
+			t.Fatalf("HTTP %d: GetResults succeeded", status)
+		}
+		if n := attempts.Load(); n != 1 {
+			t.Errorf("HTTP %d: %d attempts, want 1", status, n)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	// Retry-After is waited for instead of the (here much shorter) backoff
+	h, attempts := flaky(t, 1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"1"}})
+	c := newTestClient(t, h, nil)
+
+	start := time.Now()
+	if _, err := c.GetResults(t.Context()); err != nil {
+		t.Fatalf("GetResults: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least 1s", elapsed)
+	}
+	if n := attempts.Load(); n != 2 {
+		t.Errorf("%d attempts, want 2", n)
+	}
+
+	// Asked to wait longer than retry_max_delay: give up right away
+	h, attempts = flaky(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"3600"}})
+	c = newTestClient(t, h, nil)
+	if _, err := c.GetResults(t.Context()); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("GetResults error = %v, want ErrRateLimited", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("%d attempts, want 1", n)
+	}
+}
+
+func TestRetryDisabled(t *testing.T) {
+	h, attempts := flaky(t, 1, http.StatusServiceUnavailable, nil)
+	c := newTestClient(t, h, nil)
+	c.HTTP.Transport.(*retryTransport).retries = (&config.Config{Retries: -1}).RetryLimit()
+
+	if _, err := c.GetResults(t.Context()); err == nil {
+		t.Fatal("GetResults succeeded without retrying")
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("%d attempts, want 1", n)
+	}
+}
+
+func TestRetryTransientErrors(t *testing.T) {
+	var bodies []string
+	fails := 2
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+		if fails > 0 {
+			fails--
+			return nil, &url.Error{Op: "Post", URL: req.URL.String(), Err: syscall.ECONNRESET}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("ok")), Request: req}, nil
+	})
+	tr := newRetryTransport(rt, &config.Config{RequestsPerSecond: 1000, RetryDelay: 0.001})
+
+	// A form marked idempotent is sent again with every attempt
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, "https://bilete.loto.ro/form", strings.NewReader("email=a"))
+	req.Header.Set("Idempotency-Key", "1")
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+	if len(bodies) != 3 || bodies[2] != "email=a" {
+		t.Errorf("request bodies = %q, want 3 copies of the form", bodies)
+	}
+
+	// Permanent errors fail at once
+	calls := 0
+	rt = func(req *http.Request) (*http.Response, error) {
+		calls++
+		return nil, errors.New("unsupported protocol scheme")
+	}
+	tr.base = rt
+	if _, err := tr.RoundTrip(req); err == nil || calls != 1 {
+		t.Errorf("RoundTrip error = %v after %d calls, want a failure after 1", err, calls)
+	}
+}
+
+func TestRetryNotForPosts(t *testing.T) {
+	calls := 0
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader("")), Request: req}, nil
+	})
+	tr := newRetryTransport(rt, &config.Config{RequestsPerSecond: 1000, RetryDelay: 0.001})
+
+	// The server may have processed the login before failing, so it is not replayed
+	req, _ := http.NewRequestWithContext(t.Context(), http.MethodPost, "https://bilete.loto.ro/login", strings.NewReader("email=a"))
+	resp, err := tr.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+		t.Errorf("HTTP %d after %d calls, want 503 after 1", resp.StatusCode, calls)
+	}
+}
+
+func TestRetryCancelledBackoff(t *testing.T) {
+	h, _ := flaky(t, 1, http.StatusServiceUnavailable, http.Header{"Retry-After": {"10"}})
+	c := newTestClient(t, h, nil)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetResults(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetResults error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	tests := []struct {
+		value string
+		ok    bool
+		min   time.Duration
+	}{
+		{"", false, 0},
+		{"120", true, 2 * time.Minute},
+		{"-1", false, 0},
+		{"soon", false, 0},
+		{date, true, 58 * time.Second},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.value)
+		if ok != tt.ok || got < tt.min || got > tt.min+2*time.Second {
+			t.Errorf("retryAfter(%q) = %v, %v, want about %v, %v", tt.value, got, ok, tt.min, tt.ok)
+		}
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(100) // one request every 10ms
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.wait(t.Context()); err != nil {
+			t.Fatalf("wait: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("5 requests took %v, want at least 40ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	l.wait(ctx) // reserves a slot in the future
+	if err := l.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("wait error = %v, want context.Canceled", err)
+	}
+}
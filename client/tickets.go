@@ -1,6 +1,8 @@
 package client
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
 	"regexp"
@@ -12,36 +14,34 @@ import (
 )
 
 const (
-	ticketHistoryBaseURL = baseBileteURL + "/history/ticket"
-	ticketsPerPage       = 6
+	ticketHistoryPath = "/history/ticket"
+	ticketsPerPage    = 6
 )
 
-// GetTickets fetches a single page of ticket history and returns the tickets and total count
-func (c *Client) GetTickets(page int) ([]models.Ticket, int, error) {
-	url := fmt.Sprintf("%s?page_no=%d", ticketHistoryBaseURL, page)
+// Selectors the ticket history scraper relies on
+const (
+	ticketCardSelector       = "div.ticket-preview"
+	ticketCountSelector      = "p.small.text-muted"
+	ticketItemSelector       = "li.list-group-item"
+	ticketDetailLinkSelector = "a[href*='ticket/details']"
+	ticketPlayedSelector     = ".card-footer small"
+)
 
-	req, err := c.newRequest("GET", url)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create request: %w", err)
-	}
+// GetTickets fetches a single page of ticket history and returns the tickets and total count
+func (c *Client) GetTickets(ctx context.Context, page int) ([]models.Ticket, int, error) {
+	url := fmt.Sprintf("%s%s?page_no=%d", c.bileteBase, ticketHistoryPath, page)
 
-	resp, err := c.doRequest(req)
+	doc, err := c.getAuthenticated(ctx, url, "", "ticket history", authenticatedTitle)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to fetch tickets: %w", err)
 	}
-	defer resp.Body.Close()
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return nil, 0, fmt.Errorf("failed to parse HTML: %w", err)
-	}
 
 	// Parse total count from pagination text: "Showing 1 to 6 of 81 results"
 	total := parseTotalCount(doc)
 
 	var tickets []models.Ticket
 
-	doc.Find("div.ticket-preview").Each(func(_ int, card *goquery.Selection) {
+	doc.Find(ticketCardSelector).Each(func(_ int, card *goquery.Selection) {
 		ticket := parseTicketCard(card)
 		tickets = append(tickets, ticket)
 	})
@@ -49,9 +49,12 @@ func (c *Client) GetTickets(page int) ([]models.Ticket, int, error) {
 	return tickets, total, nil
 }
 
-// GetAllTickets fetches all pages of ticket history and enriches won tickets with prize amounts
-func (c *Client) GetAllTickets() ([]models.Ticket, error) {
-	firstPage, total, err := c.GetTickets(1)
+// GetAllTickets fetches all pages of ticket history and enriches won tickets with prize amounts.
+// Pages after the first, then the detail pages of won tickets, are fetched concurrently;
+// tickets keep the site's order. progress, if not nil, is told about each fetched page.
+// If only detail pages failed, the tickets are returned along with a *DetailsError.
+func (c *Client) GetAllTickets(ctx context.Context, progress ProgressFunc) ([]models.Ticket, error) {
+	firstPage, total, err := c.GetTickets(ctx, 1)
 	if err != nil {
 		return nil, err
 	}
@@ -60,81 +63,57 @@ func (c *Client) GetAllTickets() ([]models.Ticket, error) {
 		return firstPage, nil
 	}
 
-	allTickets := make([]models.Ticket, 0, total)
-	allTickets = append(allTickets, firstPage...)
-
 	totalPages := int(math.Ceil(float64(total) / float64(ticketsPerPage)))
-
-	for page := 2; page <= totalPages; page++ {
-		tickets, _, err := c.GetTickets(page)
+	pages := make([][]models.Ticket, totalPages)
+	pages[0] = firstPage
+	progress.report(StagePages, 1, totalPages)
+
+	err = c.forEach(ctx, totalPages-1, StagePages, func(stage string, done, total int) {
+		progress.report(stage, done+1, total+1)
+	}, func(ctx context.Context, i int) error {
+		page := i + 2
+		tickets, _, err := c.GetTickets(ctx, page)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+			return fmt.Errorf("failed to fetch page %d: %w", page, err)
 		}
+		pages[i+1] = tickets
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	allTickets := make([]models.Ticket, 0, total)
+	for _, tickets := range pages {
 		if len(tickets) == 0 {
-			break
+			break // the history shrank while paging
 		}
 		allTickets = append(allTickets, tickets...)
 	}
 
-	// Fetch prize amounts for won tickets
+	// Fetch prize amounts and played lines for won tickets
+	var won []int
 	for i := range allTickets {
 		if allTickets[i].Status == models.StatusWon && allTickets[i].DetailURL != "" {
-			prize, err := c.GetTicketPrize(allTickets[i].DetailURL)
-			if err == nil && prize != "" {
-				allTickets[i].Prize = prize
-			}
+			won = append(won, i)
 		}
 	}
-
-	return allTickets, nil
-}
-
-// GetTicketPrize fetches a ticket detail page and extracts the total prize amount.
-// The prize is in a <tfoot> row with "TOTAL CÂȘTIG" label.
-func (c *Client) GetTicketPrize(detailURL string) (string, error) {
-	req, err := c.newRequest("GET", detailURL)
-	if err != nil {
-		return "", err
-	}
-	req.Header.Set("Referer", ticketHistoryBaseURL+"?page_no=1")
-
-	resp, err := c.doRequest(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("status %d", resp.StatusCode)
-	}
-
-	doc, err := goquery.NewDocumentFromReader(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	var prize string
-	doc.Find("tfoot tr").Each(func(_ int, row *goquery.Selection) {
-		text := strings.TrimSpace(row.Text())
-		if strings.Contains(text, "TOTAL") {
-			// The last <th> in the row contains the prize amount
-			row.Find("th").Each(func(_ int, th *goquery.Selection) {
-				val := strings.TrimSpace(th.Text())
-				if strings.Contains(val, "RON") {
-					prize = val
-				}
-			})
+	if err := c.fillDetails(ctx, allTickets, won, progress); err != nil {
+		var detailsErr *DetailsError
+		if errors.As(err, &detailsErr) {
+			return allTickets, err
 		}
-	})
+		return nil, err
+	}
 
-	return prize, nil
+	return allTickets, nil
 }
 
 // parseTotalCount extracts the total ticket count from the pagination text
 func parseTotalCount(doc *goquery.Document) int {
 	var total int
 
-	doc.Find("p.small.text-muted").Each(func(_ int, s *goquery.Selection) {
+	doc.Find(ticketCountSelector).Each(func(_ int, s *goquery.Selection) {
 		text := s.Text()
 		if strings.Contains(text, "results") {
 			re := regexp.MustCompile(`of\s+(\d+)\s+results`)
@@ -152,7 +131,7 @@ func parseTotalCount(doc *goquery.Document) int {
 func parseTicketCard(card *goquery.Selection) models.Ticket {
 	var ticket models.Ticket
 
-	items := card.Find("li.list-group-item")
+	items := card.Find(ticketItemSelector)
 
 	// First li: game image and price
 	firstItem := items.First()
@@ -181,16 +160,17 @@ func parseTicketCard(card *goquery.Selection) models.Ticket {
 	})
 
 	// Detail URL
-	detailLink := card.Find("a[href*='ticket/details']")
+	detailLink := card.Find(ticketDetailLinkSelector)
 	if href, exists := detailLink.Attr("href"); exists {
 		ticket.DetailURL = href
 	}
 
 	// Played date from card footer
-	playedText := strings.TrimSpace(card.Find(".card-footer small").Text())
+	playedText := strings.TrimSpace(card.Find(ticketPlayedSelector).Text())
 	playedText = strings.TrimPrefix(playedText, "Jucat ")
 	ticket.PlayedAt = strings.TrimSpace(playedText)
 
+	ticket.Parse()
 	return ticket
 }
 
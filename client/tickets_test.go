@@ -0,0 +1,101 @@
+package client
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// historyPages are the three recorded history pages: 14 tickets, 6 per page
+var historyPages = map[string]string{
+	"1": "bilete/history_page1.html",
+	"2": "bilete/history_page2.html",
+	"3": "bilete/history_page3.html",
+}
+
+func loggedInClient(t *testing.T, site *fakeBilete) *Client {
+	t.Helper()
+	c := newTestClient(t, nil, site)
+	if err := c.Login(t.Context()); err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	clear(site.requests)
+	return c
+}
+
+func TestGetTickets(t *testing.T) {
+	c := loggedInClient(t, newFakeBilete(t, historyPages))
+
+	tickets, total, err := c.GetTickets(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("GetTickets: %v", err)
+	}
+	if total != 14 {
+		t.Errorf("total = %d, want 14", total)
+	}
+	// Page 1 has pending, won and lost cards
+	assertGolden(t, "tickets_page1", tickets)
+}
+
+func TestGetTicketsEmpty(t *testing.T) {
+	c := loggedInClient(t, newFakeBilete(t, nil))
+
+	tickets, total, err := c.GetTickets(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("GetTickets: %v", err)
+	}
+	if len(tickets) != 0 || total != 0 {
+		t.Errorf("got %d ticket(s), total %d; want none", len(tickets), total)
+	}
+}
+
+func TestGetAllTickets(t *testing.T) {
+	site := newFakeBilete(t, historyPages)
+	c := loggedInClient(t, site)
+
+	type step struct {
+		stage       string
+		done, total int
+	}
+	var steps []step
+	tickets, err := c.GetAllTickets(t.Context(), func(stage string, done, total int) {
+		steps = append(steps, step{stage, done, total})
+	})
+	if err != nil {
+		t.Fatalf("GetAllTickets: %v", err)
+	}
+	want := []step{{StagePages, 1, 3}, {StagePages, 2, 3}, {StagePages, 3, 3}, {StageDetails, 1, 2}, {StageDetails, 2, 2}}
+	if !slices.Equal(steps, want) {
+		t.Errorf("progress = %v, want %v", steps, want)
+	}
+	if len(tickets) != 14 {
+		t.Fatalf("got %d tickets, want 14", len(tickets))
+	}
+	if n := site.requests["/history/ticket"]; n != 3 {
+		t.Errorf("history requests = %d, want 3", n)
+	}
+
+	// Only won tickets have their detail page fetched
+	for _, tk := range tickets {
+		fetched := site.requests["/ticket/details/"+tk.TicketID] > 0
+		if fetched != (tk.Status == models.StatusWon) {
+			t.Errorf("ticket %s (%s): detail page fetched = %v", tk.TicketID, tk.Status, fetched)
+		}
+	}
+	assertGolden(t, "tickets_all", tickets)
+}
+
+func TestGetTicketsSessionExpired(t *testing.T) {
+	// Not logged in and no credentials to log in with: the history page redirects to the login form
+	c := newTestClient(t, nil, newFakeBilete(t, historyPages))
+	c.Config.Email, c.Config.Password = "", ""
+
+	if _, _, err := c.GetTickets(t.Context(), 1); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("GetTickets error = %v, want ErrSessionExpired", err)
+	}
+	if _, err := c.GetTicketDetails(t.Context(), "/ticket/details/700012"); !errors.Is(err, ErrSessionExpired) {
+		t.Fatalf("GetTicketDetails error = %v, want ErrSessionExpired", err)
+	}
+}
@@ -6,25 +6,75 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 const (
-	configDirName  = ".config/loto-cli"
-	configFileName = "config.json"
+	configDirName   = ".config/loto-cli"
+	configFileName  = "config.json"
+	profilesDirName = "profiles"
 )
 
 // DefaultUserAgent is the default user agent string
 const DefaultUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/144.0.0.0 Safari/537.36"
 
-// Config holds the user credentials for bilete.loto.ro
+// Defaults used when the config leaves a setting at 0
+const (
+	DefaultRequestTimeout    = 30 * time.Second
+	DefaultTimeout           = 10 * time.Minute
+	DefaultConcurrency       = 4
+	DefaultRequestsPerSecond = 5.0
+	DefaultRetries           = 3
+	DefaultRetryDelay        = time.Second
+	DefaultRetryMaxDelay     = 30 * time.Second
+)
+
+// Credential backends, selected with the credential_backend field
+const (
+	BackendConfig        = "config"         // password in this file, in plain text (default)
+	BackendEnv           = "env"            // LOTO_EMAIL and LOTO_PASSWORD environment variables
+	BackendCommand       = "command"        // output of password_command, e.g. "pass show loto"
+	BackendSecretService = "secret-service" // freedesktop Secret Service (GNOME Keyring, KWallet)
+	BackendFile          = "file"           // credentials.enc, encrypted with a passphrase
+)
+
+// Backends lists the credential backends in the order they are documented
+var Backends = []string{BackendConfig, BackendEnv, BackendCommand, BackendSecretService, BackendFile}
+
+// Config holds the user credentials for bilete.loto.ro and HTTP settings.
+// Only commands that log in need Email and Password; unless CredentialBackend
+// is "config", the password is filled in by the credentials package.
 type Config struct {
-	Email     string `json:"email"`
-	Password  string `json:"password"`
-	UserAgent string `json:"user_agent"`
+	Email          string `json:"email"`
+	Password       string `json:"password,omitempty"`
+	UserAgent      string `json:"user_agent"`
+	Proxy          string `json:"proxy,omitempty"`           // e.g. http://host:3128 or socks5://host:1080, empty uses HTTP(S)_PROXY
+	RequestTimeout int    `json:"request_timeout,omitempty"` // seconds per HTTP request, 0 uses DefaultRequestTimeout
+	Timeout        int    `json:"timeout,omitempty"`         // seconds for a whole command, 0 uses DefaultTimeout
+
+	// Where the password is kept, see Backends; empty uses BackendConfig
+	CredentialBackend string `json:"credential_backend,omitempty"`
+	PasswordCommand   string `json:"password_command,omitempty"` // run by the command backend, prints the password
+
+	// Politeness towards loto.ro when fetching many pages
+	Concurrency       int     `json:"concurrency,omitempty"`         // pages fetched at once, 0 uses DefaultConcurrency
+	RequestsPerSecond float64 `json:"requests_per_second,omitempty"` // request rate limit, 0 uses DefaultRequestsPerSecond
+
+	// Retries of requests failing with HTTP 429, 5xx or a transient network error
+	Retries       int     `json:"retries,omitempty"`         // retries per request, 0 uses DefaultRetries, -1 disables retrying
+	RetryDelay    float64 `json:"retry_delay,omitempty"`     // seconds before the first retry, doubled for each next one
+	RetryMaxDelay float64 `json:"retry_max_delay,omitempty"` // longest wait between attempts, including Retry-After
+
+	// Spending limits in RON per day, ISO week and calendar month, checked by
+	// "budget" and warned about by "tickets", "stats" and the TUI; 0 sets no limit
+	DailyLimit   float64 `json:"daily_limit,omitempty"`
+	WeeklyLimit  float64 `json:"weekly_limit,omitempty"`
+	MonthlyLimit float64 `json:"monthly_limit,omitempty"`
 }
 
-// ErrCredentialsMissing is returned when email or password is empty
-var ErrCredentialsMissing = errors.New("credentials missing: please set email and password in config file")
+// ErrCredentialsMissing is returned when email or password is empty, or the
+// credential backend holds no password
+var ErrCredentialsMissing = errors.New("credentials missing: email or password not set")
 
 // GetConfigDir returns the full path to the config directory
 func GetConfigDir() (string, error) {
@@ -35,13 +85,13 @@ func GetConfigDir() (string, error) {
 	return filepath.Join(homeDir, configDirName), nil
 }
 
-// GetConfigPath returns the full path to the config file
+// GetConfigPath returns the full path to the config file of the active profile
 func GetConfigPath() (string, error) {
-	configDir, err := GetConfigDir()
+	profileDir, err := GetProfileDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(configDir, configFileName), nil
+	return filepath.Join(profileDir, configFileName), nil
 }
 
 // EnsureExists creates the config directory and a prefilled config file if they don't exist.
@@ -59,15 +109,8 @@ func EnsureExists() (bool, error) {
 	}
 
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		emptyConfig := Config{
-			Email:     "",
-			Password:  "",
-			UserAgent: DefaultUserAgent,
-		}
-		data, err := json.MarshalIndent(emptyConfig, "", "  ")
-		if err != nil {
-			return false, err
-		}
+		// Written by hand so the empty password shows where to fill it in
+		data := fmt.Appendf(nil, "{\n  \"email\": \"\",\n  \"password\": \"\",\n  \"user_agent\": %q\n}", DefaultUserAgent)
 		if err := os.WriteFile(configPath, data, 0600); err != nil {
 			return false, err
 		}
@@ -77,7 +120,8 @@ func EnsureExists() (bool, error) {
 	return false, nil
 }
 
-// Load reads and parses the config file
+// Load reads and parses the config file, which must exist. Credentials are not
+// checked, as the password may come from another backend: see RequireCredentials.
 func Load() (*Config, error) {
 	configPath, err := GetConfigPath()
 	if err != nil {
@@ -89,18 +133,157 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, fmt.Errorf("invalid JSON in config file: %w\nPlease check the syntax at: %s", err, configPath)
+	return parse(data, configPath)
+}
+
+// Save writes the config file, creating the config directory if needed
+func Save(cfg *Config) error {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configPath, append(data, '\n'), 0600)
+}
+
+// Read decodes the config file without validating it or filling in defaults, so
+// invalid values can be inspected and fixed
+func Read() (*Config, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, errs := decode(data)
+	if len(errs) > 0 {
+		return nil, &ValidationError{Path: configPath, Errors: errs}
+	}
+	return cfg, nil
+}
+
+// LoadOptional reads the config file if it exists, without requiring credentials.
+// A missing config file yields the defaults. Used by commands that don't log in.
+func LoadOptional() (*Config, error) {
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{UserAgent: DefaultUserAgent}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return parse(data, configPath)
+}
+
+// RequireCredentials returns ErrCredentialsMissing if email or password is empty
+func (c *Config) RequireCredentials() error {
+	if c.Email == "" || c.Password == "" {
+		return ErrCredentialsMissing
+	}
+	return nil
+}
+
+// Backend returns the name of the credential backend holding the password
+func (c *Config) Backend() string {
+	if c.CredentialBackend == "" {
+		return BackendConfig
+	}
+	return c.CredentialBackend
+}
+
+// RequestTimeoutDuration returns the time limit of a single HTTP request
+func (c *Config) RequestTimeoutDuration() time.Duration {
+	if c.RequestTimeout <= 0 {
+		return DefaultRequestTimeout
+	}
+	return time.Duration(c.RequestTimeout) * time.Second
+}
+
+// TimeoutDuration returns the time limit of a whole command, e.g. fetching every ticket page
+func (c *Config) TimeoutDuration() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return time.Duration(c.Timeout) * time.Second
+}
+
+// ConcurrencyLimit returns how many pages may be fetched at once
+func (c *Config) ConcurrencyLimit() int {
+	if c.Concurrency <= 0 {
+		return DefaultConcurrency
+	}
+	return c.Concurrency
+}
+
+// RequestRate returns the maximum number of requests per second
+func (c *Config) RequestRate() float64 {
+	if c.RequestsPerSecond <= 0 {
+		return DefaultRequestsPerSecond
+	}
+	return c.RequestsPerSecond
+}
+
+// RetryLimit returns how many times a failed request is retried
+func (c *Config) RetryLimit() int {
+	switch {
+	case c.Retries < 0:
+		return 0
+	case c.Retries == 0:
+		return DefaultRetries
+	}
+	return c.Retries
+}
+
+// RetryDelayDuration returns the wait before the first retry of a request
+func (c *Config) RetryDelayDuration() time.Duration {
+	if c.RetryDelay <= 0 {
+		return DefaultRetryDelay
+	}
+	return seconds(c.RetryDelay)
+}
+
+// RetryMaxDelayDuration returns the longest wait between two attempts of a request
+func (c *Config) RetryMaxDelayDuration() time.Duration {
+	if c.RetryMaxDelay <= 0 {
+		return DefaultRetryMaxDelay
 	}
+	return seconds(c.RetryMaxDelay)
+}
+
+// seconds converts a number of seconds from the config file to a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
 
-	if cfg.Email == "" || cfg.Password == "" {
-		return nil, ErrCredentialsMissing
+// parse decodes config file contents and fills in defaults
+func parse(data []byte, configPath string) (*Config, error) {
+	cfg, errs := decode(data)
+	if len(errs) == 0 {
+		errs = cfg.Validate()
+	}
+	if len(errs) > 0 {
+		return nil, &ValidationError{Path: configPath, Errors: errs}
 	}
 
 	if cfg.UserAgent == "" {
 		cfg.UserAgent = DefaultUserAgent
 	}
-
-	return &cfg, nil
+	return cfg, nil
 }
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Keys returns the JSON keys of the config file, in the order of the Config fields
+func Keys() []string {
+	t := reflect.TypeFor[Config]()
+	keys := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		keys = append(keys, jsonKey(t.Field(i)))
+	}
+	return keys
+}
+
+// Get returns the value of a config key as text
+func (c *Config) Get(key string) (string, error) {
+	v, err := c.field(key)
+	if err != nil {
+		return "", err
+	}
+	switch v.Kind() {
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), nil
+	default:
+		return fmt.Sprint(v.Interface()), nil
+	}
+}
+
+// Set parses value for the type of a config key and assigns it. An empty value
+// resets the key to its default. The result is not validated: see Validate.
+func (c *Config) Set(key, value string) error {
+	v, err := c.field(key)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		v.SetZero()
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%s: expected a whole number, got %q", key, value)
+		}
+		v.SetInt(int64(n))
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("%s: expected a number, got %q", key, value)
+		}
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("%s cannot be set", key)
+	}
+	return nil
+}
+
+// field returns the settable field of a config key
+func (c *Config) field(key string) (reflect.Value, error) {
+	v := reflect.ValueOf(c).Elem()
+	for i := range v.NumField() {
+		if jsonKey(v.Type().Field(i)) == key {
+			return v.Field(i), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("unknown config key %q (expected one of %s)", key, strings.Join(Keys(), ", "))
+}
+
+// jsonKey returns the JSON key of a struct field
+func jsonKey(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetGet(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+	}{
+		{"email", "me@example.com", "me@example.com"},
+		{"timeout", "90", "90"},
+		{"requests_per_second", "2.5", "2.5"},
+		{"retries", "-1", "-1"},
+	}
+	for _, tt := range tests {
+		var c Config
+		if err := c.Set(tt.key, tt.value); err != nil {
+			t.Errorf("Set(%s, %q): %v", tt.key, tt.value, err)
+			continue
+		}
+		if got, err := c.Get(tt.key); err != nil || got != tt.want {
+			t.Errorf("Get(%s) = %q, %v; want %q", tt.key, got, err, tt.want)
+		}
+	}
+
+	// An empty value resets the key to its default
+	c := Config{Timeout: 90}
+	if err := c.Set("timeout", ""); err != nil || c.Timeout != 0 {
+		t.Errorf("Set(timeout, \"\") = %v, timeout %d; want it reset to 0", err, c.Timeout)
+	}
+}
+
+func TestSetErrors(t *testing.T) {
+	tests := []struct {
+		key, value, want string
+	}{
+		{"timeout", "ten", `timeout: expected a whole number, got "ten"`},
+		{"concurrency", "1.5", `concurrency: expected a whole number, got "1.5"`},
+		{"daily_limit", "5 RON", `daily_limit: expected a number, got "5 RON"`},
+		{"daily_limit", "NaN", `daily_limit: expected a number, got "NaN"`},
+		{"retry_delay", "Inf", `retry_delay: expected a number, got "Inf"`},
+		{"requests_per_second", "-infinity", `requests_per_second: expected a number, got "-infinity"`},
+		{"colour", "red", `unknown config key "colour" (expected one of email, password, user_agent, `},
+	}
+	for _, tt := range tests {
+		var c Config
+		err := c.Set(tt.key, tt.value)
+		if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+			t.Errorf("Set(%s, %q) = %v, want %s", tt.key, tt.value, err, tt.want)
+		}
+	}
+
+	var c Config
+	if _, err := c.Get("colour"); err == nil {
+		t.Error("Get(colour): expected an error for an unknown key")
+	}
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+)
+
+// DefaultProfile is the profile kept directly in the config directory, as before
+// profiles existed. Other profiles live in profiles/<name> inside it, each with its
+// own config file, saved session and archive.
+const DefaultProfile = "default"
+
+// EnvProfile selects the profile when --profile is not given
+const EnvProfile = "LOTO_PROFILE"
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// activeProfile is the profile whose files GetProfileDir points to
+var activeProfile = DefaultProfile
+
+// ErrProfileNotFound is returned when selecting or removing a profile that was never added
+var ErrProfileNotFound = errors.New("profile not found")
+
+// UseProfile selects the profile used by GetProfileDir, GetConfigPath, Load and Save.
+// The profile must exist, except the default one.
+func UseProfile(name string) error {
+	if err := checkProfileName(name); err != nil {
+		return err
+	}
+	if name != DefaultProfile {
+		dir, err := ProfileDir(name)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(dir); err != nil {
+			return fmt.Errorf("%w: %s (add it with \"loto-cli profiles add %s\")", ErrProfileNotFound, name, name)
+		}
+	}
+	activeProfile = name
+	return nil
+}
+
+// ActiveProfile returns the name of the selected profile
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// GetProfileDir returns the directory holding the files of the active profile
+func GetProfileDir() (string, error) {
+	return ProfileDir(activeProfile)
+}
+
+// ProfileDir returns the directory holding the files of a profile
+func ProfileDir(name string) (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if name == DefaultProfile {
+		return configDir, nil
+	}
+	return filepath.Join(configDir, profilesDirName, name), nil
+}
+
+// ListProfiles returns the default profile followed by the other profiles, sorted by name
+func ListProfiles() ([]string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(filepath.Join(configDir, profilesDirName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && profileNamePattern.MatchString(e.Name()) && e.Name() != DefaultProfile {
+			names = append(names, e.Name())
+		}
+	}
+	slices.Sort(names)
+	return append([]string{DefaultProfile}, names...), nil
+}
+
+// AddProfile creates the directory of a new profile
+func AddProfile(name string) error {
+	if err := checkProfileName(name); err != nil {
+		return err
+	}
+	if name == DefaultProfile {
+		return fmt.Errorf("profile %q always exists", DefaultProfile)
+	}
+
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	return os.MkdirAll(dir, 0755)
+}
+
+// RemoveProfile deletes a profile with its config file, saved session and archive
+func RemoveProfile(name string) error {
+	if err := checkProfileName(name); err != nil {
+		return err
+	}
+	if name == DefaultProfile {
+		return fmt.Errorf("profile %q cannot be removed", DefaultProfile)
+	}
+
+	dir, err := ProfileDir(name)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	return os.RemoveAll(dir)
+}
+
+// checkProfileName rejects names that are not safe as a directory name
+func checkProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use up to 32 lowercase letters, digits, '-' and '_'", name)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// minRequestsPerSecond is the slowest request rate allowed, one request every 100 seconds.
+// Slower rates would overflow the interval between requests.
+const minRequestsPerSecond = 0.01
+
+// FieldError describes a problem with one config field
+type FieldError struct {
+	Field   string `json:"field"` // JSON key, empty for the file as a whole
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError is returned when the config file is not valid JSON or has invalid values
+type ValidationError struct {
+	Path   string
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid config:")
+	for _, fe := range e.Errors {
+		b.WriteString("\n  " + fe.Error())
+	}
+	if e.Path != "" {
+		b.WriteString("\nPlease edit: " + e.Path)
+	}
+	return b.String()
+}
+
+// Validate checks every field and returns the problems found, if any
+func (c *Config) Validate() []FieldError {
+	var errs []FieldError
+	add := func(field, format string, args ...any) {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if c.Email != "" {
+		if _, err := mail.ParseAddress(c.Email); err != nil {
+			add("email", "%q is not an email address", c.Email)
+		}
+	}
+	if c.Proxy != "" {
+		u, err := url.Parse(c.Proxy)
+		if err != nil || u.Host == "" || !slices.Contains([]string{"http", "https", "socks5", "socks5h"}, u.Scheme) {
+			add("proxy", "%q is not an http://, https:// or socks5:// URL", c.Proxy)
+		}
+	}
+	if !slices.Contains(Backends, c.Backend()) {
+		add("credential_backend", "unknown backend %q (expected one of %s)", c.CredentialBackend, strings.Join(Backends, ", "))
+	}
+	if c.Backend() == BackendCommand && c.PasswordCommand == "" {
+		add("password_command", "required by credential_backend %q", BackendCommand)
+	}
+
+	if c.RequestTimeout < 0 {
+		add("request_timeout", "must be 0 (default) or a number of seconds")
+	}
+	if c.Timeout < 0 {
+		add("timeout", "must be 0 (default) or a number of seconds")
+	}
+	if c.Concurrency < 0 {
+		add("concurrency", "must be 0 (default) or positive")
+	}
+	if c.RequestsPerSecond < 0 || (c.RequestsPerSecond > 0 && c.RequestsPerSecond < minRequestsPerSecond) {
+		add("requests_per_second", "must be 0 (default) or at least %g", minRequestsPerSecond)
+	}
+	if c.Retries < -1 {
+		add("retries", "must be -1 (disabled), 0 (default) or positive")
+	}
+	if c.RetryDelay < 0 {
+		add("retry_delay", "must be 0 (default) or a number of seconds")
+	}
+	if c.RetryMaxDelay < 0 {
+		add("retry_max_delay", "must be 0 (default) or a number of seconds")
+	}
+	for _, l := range []struct {
+		key   string
+		limit float64
+	}{{"daily_limit", c.DailyLimit}, {"weekly_limit", c.WeeklyLimit}, {"monthly_limit", c.MonthlyLimit}} {
+		if l.limit < 0 {
+			add(l.key, "must be 0 (no limit) or an amount in RON")
+		}
+	}
+
+	return errs
+}
+
+// ValidateData checks config file contents, as "config validate" does: besides the
+// checks of Validate, it reports syntax errors with their position, values of the
+// wrong type and unknown keys, which loading the config silently ignores.
+func ValidateData(data []byte) []FieldError {
+	cfg, errs := decode(data)
+	if cfg == nil {
+		return errs
+	}
+
+	var raw map[string]json.RawMessage
+	_ = json.Unmarshal(data, &raw) // decode succeeded, so this does too
+	keys := Keys()
+	for key := range raw {
+		if !slices.Contains(keys, key) {
+			errs = append(errs, FieldError{Field: key, Message: "unknown key"})
+		}
+	}
+
+	errs = append(errs, cfg.Validate()...)
+	slices.SortStableFunc(errs, func(a, b FieldError) int { return strings.Compare(a.Field, b.Field) })
+	return errs
+}
+
+// decode unmarshals config file contents. Each key is decoded on its own so that
+// every value of the wrong type is reported, not only the first one. The config is
+// nil if the contents are not a JSON object.
+func decode(data []byte) (*Config, []FieldError) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, []FieldError{{Message: describeJSONError(data, err).Error()}}
+	}
+
+	var cfg Config
+	var errs []FieldError
+	for key, value := range raw {
+		f, err := cfg.field(key)
+		if err != nil {
+			continue // unknown keys are ignored, ValidateData reports them
+		}
+		if err := json.Unmarshal(value, f.Addr().Interface()); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				err = fmt.Errorf("expected a %s, got a JSON %s", typeName(typeErr.Type.Kind().String()), typeErr.Value)
+			}
+			errs = append(errs, FieldError{Field: key, Message: err.Error()})
+		}
+	}
+	slices.SortFunc(errs, func(a, b FieldError) int { return strings.Compare(a.Field, b.Field) })
+	return &cfg, errs
+}
+
+// describeJSONError adds the line and column to JSON syntax errors
+func describeJSONError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+	before := data[:min(int(syntaxErr.Offset), len(data))]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n')
+	return fmt.Errorf("line %d, column %d: %w", line, col-1, err)
+}
+
+// typeName names a Go kind the way the config documentation does
+func typeName(kind string) string {
+	switch kind {
+	case "int":
+		return "whole number"
+	case "float64":
+		return "number"
+	default:
+		return kind
+	}
+}
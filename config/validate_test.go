@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"valid", Config{Email: "me@example.com", Proxy: "socks5://localhost:1080", Retries: -1}, "[]"},
+		{"email", Config{Email: "me"}, `[email: "me" is not an email address]`},
+		{"proxy scheme", Config{Proxy: "ftp://host:21"}, `[proxy: "ftp://host:21" is not an http://, https:// or socks5:// URL]`},
+		{"proxy host", Config{Proxy: "http://"}, `[proxy: "http://" is not an http://, https:// or socks5:// URL]`},
+		{"backend", Config{CredentialBackend: "vault"},
+			`[credential_backend: unknown backend "vault" (expected one of config, env, command, secret-service, file)]`},
+		{"password command", Config{CredentialBackend: BackendCommand}, `[password_command: required by credential_backend "command"]`},
+		{"request timeout", Config{RequestTimeout: -1}, "[request_timeout: must be 0 (default) or a number of seconds]"},
+		{"timeout", Config{Timeout: -1}, "[timeout: must be 0 (default) or a number of seconds]"},
+		{"concurrency", Config{Concurrency: -1}, "[concurrency: must be 0 (default) or positive]"},
+		{"requests per second", Config{RequestsPerSecond: -0.5}, "[requests_per_second: must be 0 (default) or at least 0.01]"},
+		{"requests per second too slow", Config{RequestsPerSecond: 1e-300}, "[requests_per_second: must be 0 (default) or at least 0.01]"},
+		{"requests per second slowest", Config{RequestsPerSecond: 0.01}, "[]"},
+		{"retries", Config{Retries: -2}, "[retries: must be -1 (disabled), 0 (default) or positive]"},
+		{"retry delay", Config{RetryDelay: -1}, "[retry_delay: must be 0 (default) or a number of seconds]"},
+		{"retry max delay", Config{RetryMaxDelay: -1}, "[retry_max_delay: must be 0 (default) or a number of seconds]"},
+		{"limits", Config{DailyLimit: -1, WeeklyLimit: -1, MonthlyLimit: -1},
+			"[daily_limit: must be 0 (no limit) or an amount in RON weekly_limit: must be 0 (no limit) or an amount in RON monthly_limit: must be 0 (no limit) or an amount in RON]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(tt.cfg.Validate()); got != tt.want {
+				t.Errorf("Validate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateData(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"valid", `{"email": "me@example.com", "timeout": 60}`, "[]"},
+		{"unknown keys", `{"email": "me@example.com", "colour": "red", "api_key": "x"}`, "[api_key: unknown key colour: unknown key]"},
+		{"wrong types", `{"timeout": "60", "daily_limit": true, "email": 5}`,
+			"[daily_limit: expected a number, got a JSON bool email: expected a string, got a JSON number timeout: expected a whole number, got a JSON string]"},
+		{"syntax", "{\n  \"email\": \"me@example.com\",\n}", "[line 3, column 1: invalid character '}' looking for beginning of object key string]"},
+		{"invalid value", `{"retries": -5}`, "[retries: must be -1 (disabled), 0 (default) or positive]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(ValidateData([]byte(tt.data))); got != tt.want {
+				t.Errorf("ValidateData() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// The wording of this one comes from encoding/json
+	if errs := ValidateData([]byte(`[1, 2]`)); len(errs) != 1 || !strings.Contains(errs[0].Message, "cannot unmarshal array") {
+		t.Errorf("ValidateData(array) = %v, want a single error about the array", errs)
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	err := &ValidationError{Path: "/home/me/.config/loto-cli/config.json", Errors: []FieldError{
+		{Message: "line 1, column 2: bad"},
+		{Field: "timeout", Message: "must be 0 (default) or a number of seconds"},
+	}}
+	want := "invalid config:\n  line 1, column 2: bad\n  timeout: must be 0 (default) or a number of seconds\nPlease edit: /home/me/.config/loto-cli/config.json"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/rursache/loto-cli/config"
+)
+
+// configValidation is the output of "config validate"
+type configValidation struct {
+	Path   string              `json:"path"`
+	Valid  bool                `json:"valid"`
+	Errors []config.FieldError `json:"errors"`
+}
+
+// runConfigCmd is the CLI command handler for "config"
+func runConfigCmd(args []string) {
+	if len(args) == 0 {
+		runConfigPath()
+		return
+	}
+
+	switch args[0] {
+	case "path":
+		runConfigPath()
+	case "init":
+		runConfigInit(args[1:])
+	case "get":
+		runConfigGet(args[1:])
+	case "set":
+		runConfigSet(args[1:])
+	case "validate":
+		runConfigValidate()
+	case "edit":
+		runConfigEdit()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown config command: %s (expected init, get, set, validate or edit)\n", args[0])
+		os.Exit(1)
+	}
+}
+
+func runConfigPath() {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(configPath)
+}
+
+// runConfigInit asks for every setting needed to log in, checks the credentials
+// with a live login and writes the config file
+func runConfigInit(args []string) {
+	fs := flag.NewFlagSet("config init", flag.ContinueOnError)
+	noVerify := fs.Bool("no-verify", false, "save without logging in first")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+	if !isTerminal(os.Stdin) {
+		fmt.Fprintln(os.Stderr, `Error: config init needs an interactive terminal; use "config set" in scripts`)
+		os.Exit(1)
+	}
+
+	cfg := &config.Config{}
+	if _, err := config.EnsureExists(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating config: %v\n", err)
+		os.Exit(1)
+	}
+	if existing, err := config.Read(); err == nil {
+		cfg = existing
+	} else {
+		fmt.Fprintf(os.Stderr, "Starting from the defaults: %v\n", err)
+	}
+
+	cfg.Email = prompt("bilete.loto.ro email", cfg.Email)
+	for {
+		backend := prompt("Credential backend ("+strings.Join(config.Backends, ", ")+")", cfg.Backend())
+		if slices.Contains(config.Backends, backend) {
+			cfg.CredentialBackend = backend
+			break
+		}
+		fmt.Fprintf(os.Stderr, "Unknown backend %q\n", backend)
+	}
+	if cfg.Backend() == config.BackendCommand {
+		cfg.PasswordCommand = prompt("Command printing the password", cfg.PasswordCommand)
+	}
+	if proxy := prompt(`Proxy URL ("none" for a direct connection)`, cfg.Proxy); proxy == "none" {
+		cfg.Proxy = ""
+	} else {
+		cfg.Proxy = proxy
+	}
+
+	if errs := cfg.Validate(); len(errs) > 0 {
+		printFieldErrors(errs)
+		os.Exit(exitConfig)
+	}
+
+	// Read-only backends already hold the password; the others store the one typed here
+	writable := cfg.Backend() != config.BackendEnv && cfg.Backend() != config.BackendCommand
+	if writable {
+		password, err := promptSecret("Password")
+		if err != nil || password == "" {
+			fmt.Fprintln(os.Stderr, "Error: a password is required")
+			os.Exit(exitConfig)
+		}
+		cfg.Password = password
+	} else {
+		resolved := *cfg
+		resolveCredentials(&resolved)
+		cfg.Email, cfg.Password = resolved.Email, resolved.Password
+	}
+
+	if !*noVerify {
+		verifyLogin(cfg)
+	}
+
+	if writable {
+		storePassword(cfg)
+	}
+	if cfg.Backend() != config.BackendConfig {
+		cfg.Password = ""
+	}
+	if err := config.Save(cfg); err != nil {
+		fatal("Error saving config", err)
+	}
+
+	configPath, _ := config.GetConfigPath()
+	fmt.Printf("Config saved to %s\n", configPath)
+}
+
+// runConfigGet prints one config value, or every key and value without a key.
+// The password is masked unless asked for by name.
+func runConfigGet(args []string) {
+	if len(args) > 1 {
+		fmt.Fprintln(os.Stderr, "Usage: loto-cli config get [key]")
+		os.Exit(1)
+	}
+	cfg := mustReadConfig()
+
+	if len(args) == 1 {
+		value, err := cfg.Get(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if structured() {
+			writeStructured("config", map[string]string{args[0]: value})
+			return
+		}
+		fmt.Println(value)
+		return
+	}
+
+	if cfg.Password != "" {
+		cfg.Password = "********"
+	}
+	if structured() {
+		writeStructured("config", cfg)
+		return
+	}
+	for _, key := range config.Keys() {
+		value, _ := cfg.Get(key)
+		fmt.Printf("%-20s %s\n", key, value)
+	}
+}
+
+// runConfigSet changes one config value, refusing values that don't validate
+func runConfigSet(args []string) {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, `Usage: loto-cli config set <key> <value> ("" resets the default)`)
+		os.Exit(1)
+	}
+	if _, err := config.EnsureExists(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating config: %v\n", err)
+		os.Exit(1)
+	}
+	cfg := mustReadConfig()
+
+	if err := cfg.Set(args[0], args[1]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	if errs := cfg.Validate(); len(errs) > 0 {
+		printFieldErrors(errs)
+		os.Exit(exitConfig)
+	}
+	if err := config.Save(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving config: %v\n", err)
+		os.Exit(1)
+	}
+	if args[0] == "credential_backend" && cfg.Backend() != config.BackendConfig {
+		fmt.Fprintln(os.Stderr, `Run "loto-cli login" to store your password in the new backend.`)
+	}
+}
+
+// runConfigValidate checks the config file and exits with exitConfig if it has errors
+func runConfigValidate() {
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "No config file at %s. Run \"loto-cli config init\" to create one.\n", configPath)
+		os.Exit(exitConfig)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	errs := config.ValidateData(data)
+	if structured() {
+		if errs == nil {
+			errs = []config.FieldError{}
+		}
+		writeStructured("config_validation", configValidation{Path: configPath, Valid: len(errs) == 0, Errors: errs})
+	} else if len(errs) == 0 {
+		fmt.Printf("%s is valid\n", configPath)
+	} else {
+		fmt.Printf("%s has %d error(s):\n", configPath, len(errs))
+		for _, fe := range errs {
+			fmt.Printf("  %s\n", fe.Error())
+		}
+	}
+
+	if len(errs) > 0 {
+		os.Exit(exitConfig)
+	}
+}
+
+// runConfigEdit opens the config file in $VISUAL or $EDITOR and validates it once
+// the editor exits, offering to edit it again until it is valid
+func runConfigEdit() {
+	if _, err := config.EnsureExists(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating config: %v\n", err)
+		os.Exit(1)
+	}
+	configPath, _ := config.GetConfigPath()
+
+	for {
+		if err := openEditor(configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running the editor: %v\n", err)
+			os.Exit(1)
+		}
+
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading config: %v\n", err)
+			os.Exit(1)
+		}
+		errs := config.ValidateData(data)
+		if len(errs) == 0 {
+			fmt.Printf("%s is valid\n", configPath)
+			return
+		}
+
+		printFieldErrors(errs)
+		if !isTerminal(os.Stdin) {
+			os.Exit(exitConfig)
+		}
+		if answer := strings.ToLower(prompt("Edit again? [Y/n]", "y")); answer != "y" && answer != "yes" {
+			os.Exit(exitConfig)
+		}
+	}
+}
+
+// openEditor runs the user's editor on a file and waits for it to exit
+func openEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	var cmd *exec.Cmd
+	switch {
+	case runtime.GOOS == "windows" && editor == "":
+		cmd = exec.Command("notepad", path)
+	case runtime.GOOS == "windows":
+		cmd = exec.Command("cmd", "/C", editor+` "`+path+`"`)
+	case editor == "":
+		cmd = exec.Command("vi", path)
+	default:
+		// Through the shell, so editors with arguments such as "code --wait" work
+		cmd = exec.Command("sh", "-c", editor+` "$1"`, "sh", path)
+	}
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
+
+// mustReadConfig reads the config file without validating it, or exits
+func mustReadConfig() *config.Config {
+	cfg, err := config.Read()
+	if errors.Is(err, os.ErrNotExist) {
+		configPath, _ := config.GetConfigPath()
+		fmt.Fprintf(os.Stderr, "No config file at %s. Run \"loto-cli config init\" to create one.\n", configPath)
+		os.Exit(exitConfig)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitConfig)
+	}
+	return cfg
+}
+
+// printFieldErrors prints config validation errors on stderr
+func printFieldErrors(errs []config.FieldError) {
+	fmt.Fprintln(os.Stderr, "Invalid config:")
+	for _, fe := range errs {
+		fmt.Fprintf(os.Stderr, "  %s\n", fe.Error())
+	}
+}
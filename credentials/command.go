@@ -0,0 +1,52 @@
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// commandStore runs password_command and reads the password from the first line
+// of its output, so any password manager with a CLI can be used. The email is
+// passed in LOTO_EMAIL.
+type commandStore struct {
+	command string
+}
+
+func (s commandStore) Get(email string) (string, error) {
+	cmd := shellCommand(s.command)
+	cmd.Env = append(os.Environ(), EnvEmail+"="+email)
+	cmd.Stdin = os.Stdin // lets e.g. gpg ask for its passphrase
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%q failed: %w: %s", s.command, err, msg)
+		}
+		return "", fmt.Errorf("%q failed: %w", s.command, err)
+	}
+
+	password, _, _ := strings.Cut(string(out), "\n")
+	password = strings.TrimRight(password, "\r")
+	if password == "" {
+		return "", ErrNotFound
+	}
+	return password, nil
+}
+
+func (s commandStore) Set(email, password string) error {
+	return fmt.Errorf("%w: store the password with your password manager, read by %q", ErrReadOnly, s.command)
+}
+
+// shellCommand runs a command line with the platform shell
+func shellCommand(line string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", line)
+	}
+	return exec.Command("sh", "-c", line)
+}
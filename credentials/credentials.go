@@ -0,0 +1,119 @@
+// Package credentials reads and stores the bilete.loto.ro password in the
+// backend selected by the credential_backend config field.
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rursache/loto-cli/config"
+)
+
+// Environment variables read by the env backend; LOTO_PASSPHRASE unlocks the file backend
+const (
+	EnvEmail      = "LOTO_EMAIL"
+	EnvPassword   = "LOTO_PASSWORD"
+	EnvPassphrase = "LOTO_PASSPHRASE"
+)
+
+var (
+	// ErrNotFound is returned when the backend holds no password for the email
+	ErrNotFound = errors.New("no password stored")
+
+	// ErrReadOnly is returned by Set on backends loto-cli cannot write to
+	ErrReadOnly = errors.New("backend is read-only")
+)
+
+// Store is a credential backend: it keeps one password per email address
+type Store interface {
+	Get(email string) (string, error)
+	Set(email, password string) error
+}
+
+// PassphraseFunc asks for the passphrase of the encrypted file. confirm is true
+// when the file is about to be created, so the passphrase should be typed twice.
+type PassphraseFunc func(confirm bool) (string, error)
+
+// Open returns the backend selected in cfg. passphrase is used by the file backend
+// when LOTO_PASSPHRASE is not set; nil means the passphrase cannot be asked for.
+func Open(cfg *config.Config, passphrase PassphraseFunc) (Store, error) {
+	switch cfg.Backend() {
+	case config.BackendConfig:
+		return configStore{cfg}, nil
+	case config.BackendEnv:
+		return envStore{}, nil
+	case config.BackendCommand:
+		return commandStore{command: cfg.PasswordCommand}, nil
+	case config.BackendSecretService:
+		return secretServiceStore{}, nil
+	case config.BackendFile:
+		path, err := FilePath()
+		if err != nil {
+			return nil, err
+		}
+		return &fileStore{path: path, passphrase: passphrase}, nil
+	default:
+		return nil, fmt.Errorf("unknown credential backend %q", cfg.CredentialBackend)
+	}
+}
+
+// Resolve fills in cfg.Password (and, for the env backend, cfg.Email) from the
+// selected backend. It returns config.ErrCredentialsMissing if either is still unknown.
+func Resolve(cfg *config.Config, passphrase PassphraseFunc) error {
+	if cfg.Backend() == config.BackendEnv {
+		if email := os.Getenv(EnvEmail); email != "" {
+			cfg.Email = email
+		}
+	}
+	if cfg.Email == "" {
+		return config.ErrCredentialsMissing
+	}
+
+	store, err := Open(cfg, passphrase)
+	if err != nil {
+		return err
+	}
+	password, err := store.Get(cfg.Email)
+	if errors.Is(err, ErrNotFound) {
+		return config.ErrCredentialsMissing
+	}
+	if err != nil {
+		return fmt.Errorf("reading the password from the %s backend: %w", cfg.Backend(), err)
+	}
+
+	cfg.Password = password
+	return nil
+}
+
+// configStore keeps the password in config.json, in plain text
+type configStore struct {
+	cfg *config.Config
+}
+
+func (s configStore) Get(email string) (string, error) {
+	if s.cfg.Password == "" {
+		return "", ErrNotFound
+	}
+	return s.cfg.Password, nil
+}
+
+func (s configStore) Set(email, password string) error {
+	s.cfg.Email, s.cfg.Password = email, password
+	return config.Save(s.cfg)
+}
+
+// envStore reads the password from LOTO_PASSWORD
+type envStore struct{}
+
+func (envStore) Get(email string) (string, error) {
+	password := os.Getenv(EnvPassword)
+	if password == "" {
+		return "", ErrNotFound
+	}
+	return password, nil
+}
+
+func (envStore) Set(email, password string) error {
+	return fmt.Errorf("%w: set %s and %s in your environment", ErrReadOnly, EnvEmail, EnvPassword)
+}
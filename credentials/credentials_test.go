@@ -0,0 +1,112 @@
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/rursache/loto-cli/config"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(EnvPassphrase, "")
+
+	asked := 0
+	passphrase := func(confirm bool) (string, error) {
+		asked++
+		return "correct horse", nil
+	}
+	cfg := &config.Config{CredentialBackend: config.BackendFile}
+	store, err := Open(cfg, passphrase)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	if _, err := store.Get("a@example.com"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get before Set: %v, want ErrNotFound", err)
+	}
+	if err := store.Set("a@example.com", "s3cret"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := store.Set("b@example.com", "other"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if password, err := store.Get("a@example.com"); err != nil || password != "s3cret" {
+		t.Fatalf("Get = %q, %v, want s3cret", password, err)
+	}
+	if asked != 1 {
+		t.Errorf("passphrase asked %d times, want once", asked)
+	}
+
+	// The password is not stored in the clear
+	path, _ := FilePath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("s3cret")) || bytes.Contains(data, []byte("a@example.com")) {
+		t.Error("credentials file contains the password or email in the clear")
+	}
+
+	// A new process with the passphrase in the environment, then a wrong one
+	t.Setenv(EnvPassphrase, "correct horse")
+	cfg.Email = "b@example.com"
+	if err := Resolve(cfg, nil); err != nil || cfg.Password != "other" {
+		t.Fatalf("Resolve: password %q, %v", cfg.Password, err)
+	}
+	t.Setenv(EnvPassphrase, "wrong")
+	if err := Resolve(cfg, nil); !errors.Is(err, ErrWrongPassphrase) {
+		t.Fatalf("Resolve with a wrong passphrase: %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv(EnvEmail, "env@example.com")
+	t.Setenv(EnvPassword, "from-env")
+
+	cfg := &config.Config{Email: "file@example.com", CredentialBackend: config.BackendEnv}
+	if err := Resolve(cfg, nil); err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if cfg.Email != "env@example.com" || cfg.Password != "from-env" {
+		t.Errorf("env backend: got %s / %s", cfg.Email, cfg.Password)
+	}
+
+	// Only the env backend reads the environment
+	cfg = &config.Config{Email: "file@example.com", Password: "from-config"}
+	if err := Resolve(cfg, nil); err != nil || cfg.Email != "file@example.com" || cfg.Password != "from-config" {
+		t.Errorf("config backend: got %s / %s, %v", cfg.Email, cfg.Password, err)
+	}
+
+	cfg = &config.Config{Email: "file@example.com"}
+	if err := Resolve(cfg, nil); !errors.Is(err, config.ErrCredentialsMissing) {
+		t.Errorf("no password: %v, want ErrCredentialsMissing", err)
+	}
+}
+
+func TestCommandStore(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	script := filepath.Join(t.TempDir(), "pass")
+	os.WriteFile(script, []byte("#!/bin/sh\necho \"pw-for-$LOTO_EMAIL\"\necho second line\n"), 0755)
+
+	cfg := &config.Config{Email: "a@example.com", CredentialBackend: config.BackendCommand, PasswordCommand: script}
+	if err := Resolve(cfg, nil); err != nil || cfg.Password != "pw-for-a@example.com" {
+		t.Fatalf("Resolve: password %q, %v", cfg.Password, err)
+	}
+
+	cfg.PasswordCommand = "exit 3"
+	if err := Resolve(cfg, nil); err == nil || errors.Is(err, config.ErrCredentialsMissing) {
+		t.Errorf("failing command: %v, want its error", err)
+	}
+
+	store, _ := Open(cfg, nil)
+	if err := store.Set("a@example.com", "x"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("Set: %v, want ErrReadOnly", err)
+	}
+}
@@ -0,0 +1,181 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rursache/loto-cli/config"
+)
+
+const (
+	credentialsFileName = "credentials.enc"
+
+	// PBKDF2-HMAC-SHA256 iterations, as recommended by OWASP
+	kdfIterations = 600_000
+)
+
+// ErrWrongPassphrase is returned when the encrypted file cannot be decrypted
+var ErrWrongPassphrase = errors.New("wrong passphrase, or the credentials file is corrupted")
+
+// FilePath returns the path of the encrypted credentials file of the active profile
+func FilePath() (string, error) {
+	profileDir, err := config.GetProfileDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(profileDir, credentialsFileName), nil
+}
+
+// encryptedFile is the on-disk format of the file backend: the passwords by email,
+// as JSON, sealed with AES-256-GCM under a key derived from the passphrase
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// fileStore keeps passwords in an encrypted file. The passphrase comes from
+// LOTO_PASSPHRASE, or is asked for once per process.
+type fileStore struct {
+	path       string
+	passphrase PassphraseFunc
+	cached     string
+}
+
+func (s *fileStore) Get(email string) (string, error) {
+	passwords, err := s.read()
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	password, ok := passwords[email]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return password, nil
+}
+
+func (s *fileStore) Set(email, password string) error {
+	passwords, err := s.read()
+	if errors.Is(err, os.ErrNotExist) {
+		passwords = make(map[string]string)
+	} else if err != nil {
+		return err
+	}
+	passwords[email] = password
+	return s.write(passwords)
+}
+
+// read decrypts the file. It returns an error wrapping os.ErrNotExist if there is none.
+func (s *fileStore) read() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	var f encryptedFile
+	if err := json.Unmarshal(data, &f); err != nil || f.Version != 1 {
+		return nil, fmt.Errorf("%s is not a loto-cli credentials file", s.path)
+	}
+
+	passphrase, err := s.getPassphrase(false)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(passphrase, f.Salt, f.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, f.Nonce, f.Ciphertext, nil)
+	if err != nil {
+		s.cached = "" // ask again next time
+		return nil, ErrWrongPassphrase
+	}
+
+	var passwords map[string]string
+	if err := json.Unmarshal(plain, &passwords); err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return passwords, nil
+}
+
+// write encrypts passwords into the file with a fresh salt and nonce
+func (s *fileStore) write(passwords map[string]string) error {
+	_, statErr := os.Stat(s.path)
+	passphrase, err := s.getPassphrase(errors.Is(statErr, os.ErrNotExist))
+	if err != nil {
+		return err
+	}
+
+	f := encryptedFile{Version: 1, KDF: "pbkdf2-sha256", Iterations: kdfIterations, Salt: make([]byte, 16)}
+	rand.Read(f.Salt)
+	gcm, err := newGCM(passphrase, f.Salt, f.Iterations)
+	if err != nil {
+		return err
+	}
+	f.Nonce = make([]byte, gcm.NonceSize())
+	rand.Read(f.Nonce)
+
+	plain, err := json.Marshal(passwords)
+	if err != nil {
+		return err
+	}
+	f.Ciphertext = gcm.Seal(nil, f.Nonce, plain, nil)
+
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0600)
+}
+
+// getPassphrase returns the passphrase from LOTO_PASSPHRASE, the cache, or by asking
+func (s *fileStore) getPassphrase(confirm bool) (string, error) {
+	if p := os.Getenv(EnvPassphrase); p != "" {
+		return p, nil
+	}
+	if s.cached != "" {
+		return s.cached, nil
+	}
+	if s.passphrase == nil {
+		return "", fmt.Errorf("the file backend needs a passphrase: set %s", EnvPassphrase)
+	}
+
+	p, err := s.passphrase(confirm)
+	if err != nil {
+		return "", err
+	}
+	if p == "" {
+		return "", errors.New("empty passphrase")
+	}
+	s.cached = p
+	return p, nil
+}
+
+// newGCM derives the AES-256 key from the passphrase and returns its GCM mode
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
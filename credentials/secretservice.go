@@ -0,0 +1,163 @@
+package credentials
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	ss "github.com/zalando/go-keyring/secret_service"
+)
+
+// secretServiceStore keeps the password in the freedesktop Secret Service
+// (GNOME Keyring, KWallet, KeePassXC), talking to it over D-Bus. Items are
+// labelled "loto-cli (<email>)" and looked up by the attributes service=loto-cli
+// and username=<email>. When the session bus cannot be reached, secret-tool is
+// used instead if it is installed.
+type secretServiceStore struct{}
+
+const secretTool = "secret-tool"
+
+func (s secretServiceStore) Get(email string) (string, error) {
+	password, err := s.dbusGet(email)
+	if err == nil || errors.Is(err, ErrNotFound) || !hasSecretTool() {
+		return password, err
+	}
+	return secretToolGet(email)
+}
+
+func (s secretServiceStore) Set(email, password string) error {
+	err := s.dbusSet(email, password)
+	if err == nil || !hasSecretTool() {
+		return err
+	}
+	return secretToolSet(email, password)
+}
+
+func secretAttributes(email string) map[string]string {
+	return map[string]string{"service": "loto-cli", "username": email}
+}
+
+// dbusGet looks the password up through the org.freedesktop.secrets D-Bus API
+func (secretServiceStore) dbusGet(email string) (string, error) {
+	svc, err := ss.NewSecretService()
+	if err != nil {
+		return "", secretServiceError(err)
+	}
+	collection := svc.GetLoginCollection()
+	if err := svc.Unlock(collection.Path()); err != nil {
+		return "", secretServiceError(err)
+	}
+	session, err := svc.OpenSession()
+	if err != nil {
+		return "", secretServiceError(err)
+	}
+	defer svc.Close(session)
+
+	items, err := svc.SearchItems(collection, secretAttributes(email))
+	if err != nil {
+		return "", secretServiceError(err)
+	}
+	if len(items) == 0 {
+		return "", ErrNotFound
+	}
+	secret, err := svc.GetSecret(items[0], session.Path())
+	if err != nil {
+		return "", secretServiceError(err)
+	}
+	if len(secret.Value) == 0 {
+		return "", ErrNotFound
+	}
+	return string(secret.Value), nil
+}
+
+// dbusSet stores the password through the org.freedesktop.secrets D-Bus API,
+// replacing an item with the same attributes
+func (secretServiceStore) dbusSet(email, password string) error {
+	svc, err := ss.NewSecretService()
+	if err != nil {
+		return secretServiceError(err)
+	}
+	collection := svc.GetLoginCollection()
+	if err := svc.Unlock(collection.Path()); err != nil {
+		return secretServiceError(err)
+	}
+	session, err := svc.OpenSession()
+	if err != nil {
+		return secretServiceError(err)
+	}
+	defer svc.Close(session)
+
+	secret := ss.NewSecret(session.Path(), password)
+	if err := svc.CreateItem(collection, fmt.Sprintf("loto-cli (%s)", email), secretAttributes(email), secret); err != nil {
+		return secretServiceError(err)
+	}
+	return nil
+}
+
+// secretServiceError explains a failed D-Bus call to the Secret Service
+func secretServiceError(err error) error {
+	return fmt.Errorf("secret service: %w (is a keyring such as GNOME Keyring or KeePassXC running?)", err)
+}
+
+// hasSecretTool reports whether secret-tool can be used as a fallback
+func hasSecretTool() bool {
+	_, err := exec.LookPath(secretTool)
+	return err == nil
+}
+
+func secretToolGet(email string) (string, error) {
+	cmd, err := secretToolCommand("lookup", "service", "loto-cli", "username", email)
+	if err != nil {
+		return "", err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(out) == 0 && stderr.Len() == 0 {
+		return "", ErrNotFound // secret-tool exits 1 without output when nothing matches
+	}
+	if err != nil {
+		return "", secretToolError(err, &stderr)
+	}
+	if len(out) == 0 {
+		return "", ErrNotFound
+	}
+	return string(out), nil
+}
+
+func secretToolSet(email, password string) error {
+	cmd, err := secretToolCommand("store", "--label", fmt.Sprintf("loto-cli (%s)", email),
+		"service", "loto-cli", "username", email)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = strings.NewReader(password)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return secretToolError(err, &stderr)
+	}
+	return nil
+}
+
+// secretToolCommand returns a secret-tool command, or an error explaining how to install it
+func secretToolCommand(args ...string) (*exec.Cmd, error) {
+	path, err := exec.LookPath(secretTool)
+	if err != nil {
+		return nil, fmt.Errorf("the secret-service backend needs %s (libsecret-tools on Debian and Ubuntu, libsecret on Fedora and Arch)", secretTool)
+	}
+	return exec.Command(path, args...), nil
+}
+
+// secretToolError adds what secret-tool printed to an error
+func secretToolError(err error, stderr *bytes.Buffer) error {
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return fmt.Errorf("%s: %w: %s", secretTool, err, msg)
+	}
+	return fmt.Errorf("%s: %w", secretTool, err)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rursache/loto-cli/client"
+	"github.com/rursache/loto-cli/credentials"
+)
+
+// runDoctorCmd is the CLI command handler for "doctor"
+func runDoctorCmd(args []string) {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	dump := fs.String("dump", "", "write the raw HTML of every fetched page to this file")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	c := newPublicClient()
+	credentials.Resolve(c.Config, nil) // best effort: ticket pages are checked with credentials or a saved session
+	ctx, cancel := commandContext(c)
+	defer cancel()
+
+	d := c.Diagnose(ctx)
+
+	if *dump != "" {
+		if err := writeDump(*dump, d.Pages); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", *dump, err)
+			os.Exit(1)
+		}
+		if n := personalPages(d.Pages); n > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %s holds %d page(s) of your account, with your ticket history, ticket IDs and prizes. Check it, or remove those pages, before attaching it to a bug report.\n", *dump, n)
+		}
+	}
+
+	if structured() {
+		writeStructured("doctor", d)
+	} else {
+		printDiagnosis(d)
+		if *dump != "" {
+			fmt.Printf("\nRaw HTML of %d page(s) written to %s\n", len(d.Pages), *dump)
+		}
+	}
+
+	if d.Failed() {
+		os.Exit(1)
+	}
+}
+
+// printDiagnosis prints the checks grouped by page
+func printDiagnosis(d *client.Diagnosis) {
+	urls := make(map[string]string)
+	for _, p := range d.Pages {
+		urls[p.Name] = p.URL
+	}
+
+	page := ""
+	failed, warned := 0, 0
+	for _, p := range d.Probes {
+		if p.Page != page {
+			if page != "" {
+				fmt.Println()
+			}
+			page = p.Page
+			fmt.Printf("=== %s ===\n", page)
+			if u := urls[page]; u != "" {
+				fmt.Printf("  %s\n", u)
+			}
+		}
+
+		label := "ok"
+		switch p.Status {
+		case client.ProbeWarn:
+			label = "WARN"
+			warned++
+		case client.ProbeFail:
+			label = "FAIL"
+			failed++
+		}
+		fmt.Printf("  %-5s %-38s %s\n", label, p.Check, p.Detail)
+	}
+
+	fmt.Println()
+	if failed == 0 {
+		fmt.Printf("All checks passed (%d warning(s)).\n", warned)
+		return
+	}
+	fmt.Printf("%d check(s) failed, %d warning(s). Run with --dump <file> and attach it to a bug report once checked for personal data.\n", failed, warned)
+}
+
+// writeDump writes the raw HTML of the fetched pages to path, readable only by the
+// user since it may hold pages of their account
+func writeDump(path string, pages []client.FetchedPage) error {
+	if err := os.WriteFile(path, dumpPages(pages), 0600); err != nil {
+		return err
+	}
+	return os.Chmod(path, 0600) // WriteFile keeps the mode of an existing file
+}
+
+// personalPages counts the fetched pages that belong to the logged-in account
+func personalPages(pages []client.FetchedPage) int {
+	n := 0
+	for _, p := range pages {
+		if p.Personal {
+			n++
+		}
+	}
+	return n
+}
+
+// dumpPages concatenates the fetched pages, each preceded by a comment naming it
+func dumpPages(pages []client.FetchedPage) []byte {
+	var buf bytes.Buffer
+	for _, p := range pages {
+		personal := ""
+		if p.Personal {
+			personal = ", personal: your account's tickets"
+		}
+		fmt.Fprintf(&buf, "<!-- loto-cli doctor: %s %s (HTTP %d%s) -->\n", p.Name, p.URL, p.Status, personal)
+		buf.Write(p.Body)
+		if !bytes.HasSuffix(p.Body, []byte("\n")) {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
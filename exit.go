@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/rursache/loto-cli/client"
+	"github.com/rursache/loto-cli/config"
+	"github.com/rursache/loto-cli/credentials"
+)
+
+// Process exit codes, so scripts can tell failures apart.
+// These values are documented and must not change.
+const (
+	exitError       = 1   // any other failure, including invalid flags
+	exitConfig      = 2   // missing credentials or a config file that needs editing
+	exitAuth        = 3   // invalid credentials, an expired session or a wrong passphrase
+	exitGeoBlocked  = 4   // loto.ro refused a non-Romanian IP address
+	exitRateLimited = 5   // loto.ro is rate limiting requests
+	exitNetwork     = 6   // loto.ro could not be reached
+	exitParse       = 7   // a page loaded but could not be scraped (markup changed)
+	exitBudget      = 8   // "budget --check" found a spending limit exceeded
+	exitInterrupted = 130 // cancelled with Ctrl+C, as shells report SIGINT
+)
+
+// exitCode maps an error to the exit code describing it
+func exitCode(err error) int {
+	var parseErr *client.ParseError
+	var netErr net.Error
+	var configErr *config.ValidationError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.Is(err, config.ErrCredentialsMissing), errors.As(err, &configErr):
+		return exitConfig
+	case errors.Is(err, client.ErrInvalidCredentials), errors.Is(err, client.ErrSessionExpired),
+		errors.Is(err, credentials.ErrWrongPassphrase):
+		return exitAuth
+	case errors.Is(err, client.ErrGeoBlocked):
+		return exitGeoBlocked
+	case errors.Is(err, client.ErrRateLimited):
+		return exitRateLimited
+	case errors.As(err, &parseErr):
+		return exitParse
+	case errors.As(err, &netErr): // includes timeouts
+		return exitNetwork
+	default:
+		return exitError
+	}
+}
+
+// fatal prints an error after a short description of what failed and exits with its exit code
+func fatal(what string, err error) {
+	fmt.Fprintf(os.Stderr, "%s: %v\n", what, err)
+
+	var parseErr *client.ParseError
+	if errors.As(err, &parseErr) {
+		fmt.Fprintln(os.Stderr, `loto.ro may have changed its pages. Run "loto-cli doctor" for details.`)
+	}
+
+	os.Exit(exitCode(err))
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// ticketColumns are the columns of a ticket export, shared by CSV and XLSX
+var ticketColumns = []string{"played_at", "draw_date", "game", "ticket_id", "order_id", "status", "price", "prize", "net", "currency"}
+
+// summaryColumns are the columns of a monthly summary, shared by CSV and XLSX
+var summaryColumns = []string{"month", "tickets", "won", "spent", "won_amount", "net", "currency"}
+
+// writeCSV writes one row per ticket. Dates are ISO 8601 in local time and amounts
+// use a decimal point, so spreadsheets parse them in any locale.
+func writeCSV(w io.Writer, tickets []models.Ticket) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ticketColumns); err != nil {
+		return err
+	}
+
+	for _, t := range tickets {
+		played := ""
+		if !t.PlayedTime.IsZero() {
+			played = t.PlayedTime.Format("2006-01-02 15:04")
+		}
+		draw := ""
+		if !t.DrawTime.IsZero() {
+			draw = t.DrawTime.Format("2006-01-02")
+		}
+		prize := ""
+		if !t.PrizeAmount.IsZero() {
+			prize = t.PrizeAmount.Decimal()
+		}
+
+		if err := cw.Write([]string{
+			played,
+			draw,
+			string(t.Game),
+			t.TicketID,
+			t.OrderID,
+			t.Status.Key(),
+			t.PriceAmount.Decimal(),
+			prize,
+			t.PrizeAmount.Sub(t.PriceAmount).Decimal(),
+			currency(t.PriceAmount),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeSummaryCSV writes one row per month
+func writeSummaryCSV(w io.Writer, months []MonthSummary) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(summaryColumns); err != nil {
+		return err
+	}
+
+	for _, ms := range months {
+		if err := cw.Write([]string{
+			ms.Month,
+			strconv.Itoa(ms.Tickets),
+			strconv.Itoa(ms.Won),
+			ms.Spent.Decimal(),
+			ms.WonAmount.Decimal(),
+			ms.Net.Decimal(),
+			currency(ms.Spent),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// currency returns an amount's currency, RON if it is unknown
+func currency(m models.Money) string {
+	if m.Currency == "" {
+		return models.CurrencyRON
+	}
+	return m.Currency
+}
@@ -0,0 +1,123 @@
+// Package export writes a ticket history in formats spreadsheets and accounting
+// tools can import: CSV, XLSX, OFX and JSON.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rursache/loto-cli/analytics"
+	"github.com/rursache/loto-cli/models"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatOFX  Format = "ofx"
+	FormatJSON Format = "json"
+)
+
+// Formats lists every supported format
+var Formats = []Format{FormatCSV, FormatXLSX, FormatOFX, FormatJSON}
+
+// ParseFormat maps a user-supplied format name to a Format
+func ParseFormat(name string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(name)))
+	if slices.Contains(Formats, f) {
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown export format %q (expected csv, xlsx, ofx or json)", name)
+}
+
+// FormatFromPath picks the format matching a file's extension
+func FormatFromPath(path string) (Format, bool) {
+	f, err := ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+	return f, err == nil
+}
+
+// Options controls what is exported
+type Options struct {
+	// Summary adds per-month totals: a second XLSX sheet, a "months" list in JSON,
+	// or, for CSV, the monthly totals instead of the tickets. OFX has no room for them.
+	Summary bool
+	// Account identifies the statement in OFX files, e.g. the bilete.loto.ro email
+	Account string
+	// Now is the export time written to OFX and JSON files; zero uses the current time
+	Now time.Time
+}
+
+// MonthSummary totals the tickets played in one calendar month
+type MonthSummary struct {
+	Month     string       `json:"month"` // e.g. "2026-02"
+	Tickets   int          `json:"tickets"`
+	Won       int          `json:"won"`
+	Spent     models.Money `json:"spent"`
+	WonAmount models.Money `json:"won_amount"`
+	Net       models.Money `json:"net"` // WonAmount minus Spent
+}
+
+// Write exports tickets in the given format
+func Write(w io.Writer, format Format, tickets []models.Ticket, opt Options) error {
+	if opt.Now.IsZero() {
+		opt.Now = time.Now()
+	}
+
+	switch format {
+	case FormatCSV:
+		if opt.Summary {
+			return writeSummaryCSV(w, Summarize(tickets))
+		}
+		return writeCSV(w, tickets)
+	case FormatXLSX:
+		return writeXLSX(w, tickets, opt)
+	case FormatOFX:
+		if opt.Summary {
+			return fmt.Errorf("OFX statements cannot hold monthly summaries")
+		}
+		return writeOFX(w, tickets, opt)
+	case FormatJSON:
+		return writeJSON(w, tickets, opt)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// Summarize totals tickets per month they were played in (the draw month if the
+// time played is unknown), oldest month first. Tickets without either date are left out.
+func Summarize(tickets []models.Ticket) []MonthSummary {
+	buckets := analytics.Breakdown(tickets, analytics.Month)
+	months := make([]MonthSummary, len(buckets))
+	for i, b := range buckets {
+		months[i] = MonthSummary{Month: b.Key, Tickets: b.Tickets, Won: b.Won, Spent: b.Spent, WonAmount: b.WonAmount, Net: b.Net}
+	}
+	return months
+}
+
+// jsonExport is the document written in JSON format
+type jsonExport struct {
+	ExportedAt time.Time       `json:"exported_at"`
+	Tickets    []models.Ticket `json:"tickets"`
+	Months     []MonthSummary  `json:"months,omitempty"`
+}
+
+func writeJSON(w io.Writer, tickets []models.Ticket, opt Options) error {
+	doc := jsonExport{ExportedAt: opt.Now, Tickets: tickets}
+	if doc.Tickets == nil {
+		doc.Tickets = []models.Ticket{}
+	}
+	if opt.Summary {
+		doc.Months = Summarize(tickets)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
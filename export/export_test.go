@@ -0,0 +1,142 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+func testTickets() []models.Ticket {
+	tickets := []models.Ticket{
+		{OrderID: "O1", TicketID: "T1", Game: models.GameLoto649, Price: "24,50 RON", Prize: "120,00 RON", DrawDate: "12.02.2026", Status: models.StatusWon, PlayedAt: "Jo 12 feb 2026, Ora 18:58"},
+		{OrderID: "O2", TicketID: "T2", Game: models.GameJoker, Price: "10,00 RON", DrawDate: "08.02.2026", Status: models.StatusLost, PlayedAt: "Jo 5 feb 2026, Ora 10:00"},
+		{OrderID: "O3", TicketID: "T<3>", Game: models.GameLoto540, Price: "5,00 RON", DrawDate: "15.01.2026", Status: models.StatusPending},
+	}
+	for i := range tickets {
+		tickets[i].Parse()
+	}
+	return tickets
+}
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, testTickets(), Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `played_at,draw_date,game,ticket_id,order_id,status,price,prize,net,currency
+2026-02-12 18:58,2026-02-12,Loto 6/49,T1,O1,won,24.50,120.00,95.50,RON
+2026-02-05 10:00,2026-02-08,Joker,T2,O2,lost,10.00,,-10.00,RON
+,2026-01-15,Loto 5/40,T<3>,O3,pending,5.00,,-5.00,RON
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	months := Summarize(testTickets())
+	if len(months) != 2 {
+		t.Fatalf("got %d months, want 2: %+v", len(months), months)
+	}
+
+	jan, feb := months[0], months[1]
+	if jan.Month != "2026-01" || jan.Tickets != 1 || jan.Spent != models.RON(5, 0) || jan.Net != models.RON(-5, 0) {
+		t.Errorf("January = %+v", jan)
+	}
+	if feb.Month != "2026-02" || feb.Tickets != 2 || feb.Won != 1 || feb.WonAmount != models.RON(120, 0) || feb.Net != models.RON(85, 50) {
+		t.Errorf("February = %+v", feb)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatXLSX, testTickets(), Options{Summary: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		if err := xml.Unmarshal(data, new(struct{})); err != nil {
+			t.Errorf("%s is not well-formed XML: %v", f.Name, err)
+		}
+		parts[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "xl/workbook.xml", "xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A2" s="2"><v>46065.79027777778</v></c>`, // 12.02.2026 18:58
+		`<c r="G2" s="3"><v>24.50</v></c>`,
+		`<t>T&lt;3&gt;</t>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1 lacks %s", want)
+		}
+	}
+	if strings.Contains(sheet, `r="A4"`) {
+		t.Error("unknown time played was written as a cell")
+	}
+}
+
+func TestWriteOFX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatOFX, testTickets(), Options{Account: "me@example.com", Now: testNow}); err != nil {
+		t.Fatal(err)
+	}
+	ofx := buf.String()
+
+	for _, want := range []string{
+		"<ACCTID>me@example.com</ACCTID>",
+		"<TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20260212185800</DTPOSTED><TRNAMT>-24.50</TRNAMT><FITID>T1</FITID>",
+		"<TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20260212000000</DTPOSTED><TRNAMT>120.00</TRNAMT><FITID>T1-prize</FITID>",
+		"<FITID>T&lt;3&gt;</FITID>",
+		"<BALAMT>80.50</BALAMT>",
+	} {
+		if !strings.Contains(ofx, want) {
+			t.Errorf("OFX lacks %s", want)
+		}
+	}
+	if strings.Count(ofx, "<STMTTRN>") != 4 {
+		t.Errorf("got %d transactions, want 4", strings.Count(ofx, "<STMTTRN>"))
+	}
+
+	if err := Write(io.Discard, FormatOFX, nil, Options{Summary: true}); err == nil {
+		t.Error("OFX with a summary did not fail")
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	tests := map[string]Format{"spend.xlsx": FormatXLSX, "a/b.OFX": FormatOFX, "t.csv": FormatCSV, "t.json": FormatJSON}
+	for path, want := range tests {
+		if got, ok := FormatFromPath(path); !ok || got != want {
+			t.Errorf("FormatFromPath(%q) = %q, %v; want %q", path, got, ok, want)
+		}
+	}
+	if _, ok := FormatFromPath("tickets.txt"); ok {
+		t.Error("FormatFromPath accepted .txt")
+	}
+}
@@ -0,0 +1,116 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/rursache/loto-cli/analytics"
+	"github.com/rursache/loto-cli/models"
+)
+
+// ofxTime is the OFX date-time layout, written in local time
+const ofxTime = "20060102150405"
+
+// writeOFX writes an OFX 2.2 bank statement: a debit for every ticket bought,
+// dated when it was played, and a credit for every prize, dated on its draw day.
+// Transaction IDs derive from the ticket ID, so importing a later export again
+// only adds the new transactions.
+func writeOFX(w io.Writer, tickets []models.Ticket, opt Options) error {
+	account := opt.Account
+	if account == "" {
+		account = "bilete.loto.ro"
+	}
+
+	type transaction struct {
+		kind   string // DEBIT or CREDIT
+		posted time.Time
+		amount models.Money
+		id     string
+		name   string
+		memo   string
+	}
+	var txns []transaction
+	var balance models.Money
+	var start, end time.Time
+	for _, t := range tickets {
+		played := analytics.PlayedAt(t)
+		if played.IsZero() {
+			continue
+		}
+		txns = append(txns, transaction{
+			kind:   "DEBIT",
+			posted: played,
+			amount: models.Money{Amount: -t.PriceAmount.Amount, Currency: t.PriceAmount.Currency},
+			id:     t.TicketID,
+			name:   string(t.Game),
+			memo:   fmt.Sprintf("Ticket %s, draw %s", t.TicketID, t.DrawDate),
+		})
+		balance = balance.Sub(t.PriceAmount)
+
+		if t.Status == models.StatusWon && !t.PrizeAmount.IsZero() {
+			posted := t.DrawTime
+			if posted.IsZero() {
+				posted = played
+			}
+			txns = append(txns, transaction{
+				kind:   "CREDIT",
+				posted: posted,
+				amount: t.PrizeAmount,
+				id:     t.TicketID + "-prize",
+				name:   string(t.Game) + " prize",
+				memo:   fmt.Sprintf("Ticket %s, draw %s", t.TicketID, t.DrawDate),
+			})
+			balance = balance.Add(t.PrizeAmount)
+		}
+
+		for _, at := range []time.Time{played, t.DrawTime} {
+			if at.IsZero() {
+				continue
+			}
+			if start.IsZero() || at.Before(start) {
+				start = at
+			}
+			if at.After(end) {
+				end = at
+			}
+		}
+	}
+	if start.IsZero() {
+		start, end = opt.Now, opt.Now
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n")
+	b.WriteString(`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n")
+	b.WriteString("<OFX>\n")
+	b.WriteString("<SIGNONMSGSRSV1><SONRS>\n")
+	b.WriteString("<STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	fmt.Fprintf(&b, "<DTSERVER>%s</DTSERVER><LANGUAGE>ENG</LANGUAGE>\n", opt.Now.Format(ofxTime))
+	b.WriteString("</SONRS></SIGNONMSGSRSV1>\n")
+	b.WriteString("<BANKMSGSRSV1><STMTTRNRS>\n")
+	b.WriteString("<TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>\n")
+	fmt.Fprintf(&b, "<STMTRS><CURDEF>%s</CURDEF>\n", models.CurrencyRON)
+	fmt.Fprintf(&b, "<BANKACCTFROM><BANKID>LOTO</BANKID><ACCTID>%s</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>\n", escape(account))
+	fmt.Fprintf(&b, "<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>\n", start.Format(ofxTime), end.Format(ofxTime))
+	for _, tx := range txns {
+		fmt.Fprintf(&b, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%s</FITID><NAME>%s</NAME><MEMO>%s</MEMO></STMTTRN>\n",
+			tx.kind, tx.posted.Format(ofxTime), tx.amount.Decimal(), escape(tx.id), escape(tx.name), escape(tx.memo))
+	}
+	b.WriteString("</BANKTRANLIST>\n")
+	fmt.Fprintf(&b, "<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>\n", balance.Decimal(), opt.Now.Format(ofxTime))
+	b.WriteString("</STMTRS></STMTTRNRS></BANKMSGSRSV1>\n")
+	b.WriteString("</OFX>\n")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// escape escapes text for an XML element
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,235 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// Cell styles, indexes into cellXfs of xlsxStyles
+const (
+	styleDefault = iota
+	styleDate
+	styleDateTime
+	styleMoney
+	styleHeader
+)
+
+// cell is a single spreadsheet value: a string, int, models.Money or time.Time.
+// A nil value or zero time leaves the cell empty.
+type cell struct {
+	value any
+	style int
+}
+
+// sheet is a named worksheet whose first row is a header
+type sheet struct {
+	name string
+	rows [][]cell
+}
+
+// writeXLSX writes an Office Open XML workbook with a Tickets sheet and, with
+// opt.Summary, a Monthly sheet. Dates and amounts are typed cells, so they sort
+// and sum in any spreadsheet.
+func writeXLSX(w io.Writer, tickets []models.Ticket, opt Options) error {
+	sheets := []sheet{ticketSheet(tickets)}
+	if opt.Summary {
+		sheets = append(sheets, summarySheet(Summarize(tickets)))
+	}
+
+	files := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes(len(sheets))},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook(sheets)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(sheets))},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, s := range sheets {
+		files = append(files, struct{ name, body string }{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), s.xml()})
+	}
+
+	zw := zip.NewWriter(w)
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, f.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// ticketSheet lays out tickets with the same columns as the CSV export
+func ticketSheet(tickets []models.Ticket) sheet {
+	s := sheet{name: "Tickets", rows: [][]cell{headerRow(ticketColumns)}}
+	for _, t := range tickets {
+		var prize any
+		if !t.PrizeAmount.IsZero() {
+			prize = t.PrizeAmount
+		}
+		s.rows = append(s.rows, []cell{
+			{t.PlayedTime, styleDateTime},
+			{t.DrawTime, styleDate},
+			{string(t.Game), styleDefault},
+			{t.TicketID, styleDefault},
+			{t.OrderID, styleDefault},
+			{t.Status.Key(), styleDefault},
+			{t.PriceAmount, styleMoney},
+			{prize, styleMoney},
+			{t.PrizeAmount.Sub(t.PriceAmount), styleMoney},
+			{currency(t.PriceAmount), styleDefault},
+		})
+	}
+	return s
+}
+
+// summarySheet lays out monthly totals with the same columns as the CSV summary
+func summarySheet(months []MonthSummary) sheet {
+	s := sheet{name: "Monthly", rows: [][]cell{headerRow(summaryColumns)}}
+	for _, ms := range months {
+		s.rows = append(s.rows, []cell{
+			{ms.Month, styleDefault},
+			{ms.Tickets, styleDefault},
+			{ms.Won, styleDefault},
+			{ms.Spent, styleMoney},
+			{ms.WonAmount, styleMoney},
+			{ms.Net, styleMoney},
+			{currency(ms.Spent), styleDefault},
+		})
+	}
+	return s
+}
+
+func headerRow(columns []string) []cell {
+	row := make([]cell, len(columns))
+	for i, c := range columns {
+		row[i] = cell{c, styleHeader}
+	}
+	return row
+}
+
+// xml renders the worksheet part. Strings are written inline, so the workbook
+// needs no shared strings table.
+func (s sheet) xml() string {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	b.WriteString(`<sheetData>`)
+	for r, row := range s.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cl := range row {
+			ref := columnName(c) + strconv.Itoa(r+1)
+			switch v := cl.value.(type) {
+			case string:
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t>`, ref, cl.style)
+				xml.EscapeText(&b, []byte(v))
+				b.WriteString(`</t></is></c>`)
+			case int:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%d</v></c>`, ref, cl.style, v)
+			case models.Money:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cl.style, v.Decimal())
+			case time.Time:
+				if !v.IsZero() {
+					fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cl.style, strconv.FormatFloat(serialDate(v), 'f', -1, 64))
+				}
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// columnName returns the spreadsheet name of a zero-based column: A, B, ..., Z, AA, ...
+func columnName(i int) string {
+	name := ""
+	for i >= 0 {
+		name = string(rune('A'+i%26)) + name
+		i = i/26 - 1
+	}
+	return name
+}
+
+// serialDate converts a time to a spreadsheet serial date: days since 30.12.1899,
+// with the time of day as the fraction. The wall clock time is kept as is.
+func serialDate(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	epoch := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	const day = 24 * time.Hour
+	since := wall.Sub(epoch)
+	return float64(since/day) + float64(since%day)/float64(day)
+}
+
+func xlsxContentTypes(sheets int) string {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+const xlsxRootRels = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+func xlsxWorkbook(sheets []sheet) string {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, s := range sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, s.name, i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+// xlsxWorkbookRels links the worksheets as rId1..rIdN and the styles after them
+func xlsxWorkbookRels(sheets int) string {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheets+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+// xlsxStyles defines the cell styles in the order of the style constants
+const xlsxStyles = xml.Header +
+	`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="3">` +
+	`<numFmt numFmtId="164" formatCode="yyyy-mm-dd"/>` +
+	`<numFmt numFmtId="165" formatCode="yyyy-mm-dd hh:mm"/>` +
+	`<numFmt numFmtId="166" formatCode="#,##0.00"/>` +
+	`</numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="166" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`
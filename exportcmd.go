@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rursache/loto-cli/config"
+	"github.com/rursache/loto-cli/export"
+	"github.com/rursache/loto-cli/models"
+)
+
+// runExportCmd is the CLI command handler for "export"
+func runExportCmd(args []string) {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := fs.String("format", "", "csv, xlsx, ofx or json (default: from the --out extension, else csv)")
+	fs.StringVar(format, "f", "", "shorthand for --format")
+	out := fs.String("out", "", "file to write (default: standard output, except for xlsx)")
+	fs.StringVar(out, "o", "", "shorthand for --out")
+	summary := fs.Bool("summary", false, "add per-month totals (csv: write only the monthly totals)")
+	game := fs.String("game", "", "only export tickets of this game")
+	from := fs.String("from", "", "only export tickets for draws on or after this date (DD.MM.YYYY)")
+	to := fs.String("to", "", "only export tickets for draws on or before this date (DD.MM.YYYY)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	f, err := exportFormat(*format, *out)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if f == export.FormatXLSX && *out == "" {
+		fmt.Fprintln(os.Stderr, "Error: xlsx exports need --out <file>")
+		os.Exit(1)
+	}
+	if f == export.FormatOFX && *summary {
+		fmt.Fprintln(os.Stderr, "Error: --summary is not supported for ofx")
+		os.Exit(1)
+	}
+	q, err := parseTicketQuery(*game, "", *from, *to, "", "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	exportOpts := export.Options{Summary: *summary}
+	if cfg, err := config.LoadOptional(); err == nil {
+		exportOpts.Account = cfg.Email
+	}
+
+	// The prizes of won tickets are read from their detail pages along with the history
+	withTickets(false, func(tickets []models.Ticket) {
+		tickets = q.Apply(tickets)
+		if err := writeExport(*out, f, tickets, exportOpts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting tickets: %v\n", err)
+			os.Exit(1)
+		}
+		if *out != "" {
+			fmt.Fprintf(os.Stderr, "Exported %d ticket(s) to %s\n", len(tickets), *out)
+		}
+	})
+}
+
+// exportFormat picks the export format from --format, or else the --out extension
+func exportFormat(format, out string) (export.Format, error) {
+	if format != "" {
+		return export.ParseFormat(format)
+	}
+	if f, ok := export.FormatFromPath(out); ok {
+		return f, nil
+	}
+	return export.FormatCSV, nil
+}
+
+// writeExport writes the export to path, or to standard output if path is empty.
+// A partly written file is removed.
+func writeExport(path string, format export.Format, tickets []models.Ticket, opt export.Options) error {
+	if path == "" {
+		return export.Write(os.Stdout, format, tickets, opt)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = export.Write(f, format, tickets, opt)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
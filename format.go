@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+)
+
+// schemaVersion is the version of the JSON output schema.
+// Bump it whenever a field is removed, renamed or changes meaning.
+const schemaVersion = 1
+
+// outputFormat selects how command output is rendered
+type outputFormat string
+
+const (
+	formatText   outputFormat = "text"
+	formatJSON   outputFormat = "json"
+	formatNDJSON outputFormat = "ndjson"
+)
+
+// envelope wraps every JSON document (json format) and every line (ndjson format)
+type envelope struct {
+	SchemaVersion int    `json:"schema_version"`
+	Kind          string `json:"kind"`
+	Data          any    `json:"data"`
+}
+
+// writeStructured writes data in the selected machine-readable format.
+// In ndjson format, slices are written one element per line; anything else is a single line.
+func writeStructured(kind string, data any) {
+	v := reflect.ValueOf(data)
+	isSlice := v.Kind() == reflect.Slice
+	if isSlice && v.IsNil() {
+		data = []any{} // encode empty lists as [] rather than null
+	}
+
+	var err error
+	switch opts.format {
+	case formatNDJSON:
+		enc := json.NewEncoder(os.Stdout)
+		if isSlice {
+			for i := 0; i < v.Len() && err == nil; i++ {
+				err = enc.Encode(envelope{SchemaVersion: schemaVersion, Kind: kind, Data: v.Index(i).Interface()})
+			}
+		} else {
+			err = enc.Encode(envelope{SchemaVersion: schemaVersion, Kind: kind, Data: data})
+		}
+	default:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(envelope{SchemaVersion: schemaVersion, Kind: kind, Data: data})
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing output: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// structured reports whether output should be machine-readable
+func structured() bool {
+	return opts.format != formatText
+}
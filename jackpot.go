@@ -0,0 +1,103 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// runJackpotCmd is the CLI command handler for "jackpot"
+func runJackpotCmd(args []string) {
+	fs := flag.NewFlagSet("jackpot", flag.ContinueOnError)
+	gameName := fs.String("game", "", "only show this game (e.g. \"Loto 6/49\", 649, joker, noroc)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	c := newPublicClient()
+	ctx, cancel := commandContext(c)
+	defer cancel()
+
+	var reports []models.PrizeReport
+	var err error
+	if *gameName == "" {
+		reports, err = c.GetPrizeReports(ctx)
+	} else {
+		var game models.Game
+		if game, err = models.ParseGame(*gameName); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		reports, err = c.GetPrizeReport(ctx, mainGameOf(game))
+		reports = filterReports(reports, game)
+	}
+	if err != nil {
+		fatal("Error fetching prize reports", err)
+	}
+
+	if structured() {
+		writeStructured("jackpot", reports)
+		return
+	}
+
+	if len(reports) == 0 {
+		fmt.Println("No prize reports found.")
+		return
+	}
+
+	for i, r := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+		title := string(r.Game)
+		if r.Date != "" {
+			title += " (" + r.Date + ")"
+		}
+		fmt.Printf("=== %s ===\n", title)
+		if r.Jackpot != "" {
+			next := ""
+			if r.NextDraw != "" {
+				next = " on " + r.NextDraw
+			}
+			fmt.Printf("Next draw jackpot%s: %s\n", next, r.Jackpot)
+		}
+
+		fmt.Printf("%-6s %10s  %-18s %s\n", "Cat.", "Winners", "Prize", "Rollover")
+		fmt.Println(strings.Repeat("-", 54))
+		for _, p := range r.Categories {
+			fmt.Printf("%-6s %10d  %-18s %s\n", p.Category, p.Winners, orDash(p.Prize), orDash(p.Rollover))
+		}
+	}
+}
+
+// mainGameOf returns the game whose page reports g: the game itself, or the game a Noroc game is played with
+func mainGameOf(g models.Game) models.Game {
+	for _, main := range []models.Game{models.GameLoto649, models.GameLoto540, models.GameJoker} {
+		if models.NorocGameFor(main) == g {
+			return main
+		}
+	}
+	return g
+}
+
+// filterReports keeps the reports of one game
+func filterReports(reports []models.PrizeReport, game models.Game) []models.PrizeReport {
+	var filtered []models.PrizeReport
+	for _, r := range reports {
+		if r.Game == game {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// orDash returns s, or "-" if it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
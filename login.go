@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+
+	"github.com/rursache/loto-cli/config"
+	"github.com/rursache/loto-cli/credentials"
+)
+
+// stdin is shared by every prompt, so lines typed ahead are not lost between them
+var stdin = bufio.NewReader(os.Stdin)
+
+// runLoginCmd is the CLI command handler for "login": it asks for the email and
+// password, checks them against bilete.loto.ro and stores the password in the
+// configured credential backend
+func runLoginCmd(args []string) {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	backend := fs.String("backend", "", "credential backend to use from now on: "+strings.Join(config.Backends, ", "))
+	noVerify := fs.Bool("no-verify", false, "store the password without logging in first")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	if _, err := config.EnsureExists(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating config: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("Error loading config", err)
+	}
+
+	if *backend != "" {
+		if !slices.Contains(config.Backends, *backend) {
+			fmt.Fprintf(os.Stderr, "Error: unknown backend %q (expected one of %s)\n", *backend, strings.Join(config.Backends, ", "))
+			os.Exit(1)
+		}
+		cfg.CredentialBackend = *backend
+	}
+
+	switch cfg.Backend() {
+	case config.BackendEnv:
+		fmt.Fprintf(os.Stderr, "The env backend reads %s and %s; set them in your environment instead.\n", credentials.EnvEmail, credentials.EnvPassword)
+		os.Exit(exitConfig)
+	case config.BackendCommand:
+		if cfg.PasswordCommand == "" {
+			configPath, _ := config.GetConfigPath()
+			fmt.Fprintf(os.Stderr, "The command backend needs a password_command. Please edit: %s\n", configPath)
+			os.Exit(exitConfig)
+		}
+		fmt.Fprintf(os.Stderr, "The command backend reads the password from %q; store it with your password manager instead.\n", cfg.PasswordCommand)
+		os.Exit(exitConfig)
+	}
+
+	if !isTerminal(os.Stdin) {
+		fmt.Fprintln(os.Stderr, "Error: login needs an interactive terminal")
+		os.Exit(1)
+	}
+
+	email := prompt("Email", cfg.Email)
+	password, err := promptSecret("Password")
+	if err != nil || email == "" || password == "" {
+		fmt.Fprintln(os.Stderr, "Error: email and password are required")
+		os.Exit(exitConfig)
+	}
+	cfg.Email, cfg.Password = email, password
+
+	if !*noVerify {
+		verifyLogin(cfg)
+	}
+	storePassword(cfg)
+	fmt.Printf("Password for %s stored in the %s backend.\n", email, cfg.Backend())
+}
+
+// verifyLogin logs in with the credentials in cfg, or exits
+func verifyLogin(cfg *config.Config) {
+	c := mustNewClient(cfg)
+	ctx, cancel := commandContext(c)
+	defer cancel()
+
+	fmt.Fprintln(os.Stderr, "Logging in to loto.ro...")
+	if err := c.Login(ctx); err != nil {
+		fatal("Login error", err)
+	}
+}
+
+// storePassword stores cfg.Password in the configured backend and saves the config
+// file, or exits. Except with the config backend, the password is kept out of the
+// file, including one left from before.
+func storePassword(cfg *config.Config) {
+	store, err := credentials.Open(cfg, promptPassphrase())
+	if err != nil {
+		fatal("Error", err)
+	}
+	if err := store.Set(cfg.Email, cfg.Password); err != nil {
+		fatal(fmt.Sprintf("Error storing the password in the %s backend", cfg.Backend()), err)
+	}
+
+	if cfg.Backend() != config.BackendConfig {
+		saved := *cfg
+		saved.Password = ""
+		if err := config.Save(&saved); err != nil {
+			fatal("Error saving config", err)
+		}
+	}
+}
+
+// resolveCredentials fills in the password from the configured backend, or exits
+// explaining where to put it
+func resolveCredentials(cfg *config.Config) {
+	err := credentials.Resolve(cfg, promptPassphrase())
+	if err == nil {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if errors.Is(err, config.ErrCredentialsMissing) {
+		switch cfg.Backend() {
+		case config.BackendConfig:
+			configPath, _ := config.GetConfigPath()
+			fmt.Fprintf(os.Stderr, "Please edit: %s\n", configPath)
+		case config.BackendEnv:
+			fmt.Fprintf(os.Stderr, "Please set %s and %s\n", credentials.EnvEmail, credentials.EnvPassword)
+		default:
+			fmt.Fprintln(os.Stderr, `Run "loto-cli login" to store your password`)
+		}
+	}
+	os.Exit(exitCode(err))
+}
+
+// promptPassphrase returns a prompt for the passphrase of the encrypted credentials
+// file, or nil when stdin is not a terminal
+func promptPassphrase() credentials.PassphraseFunc {
+	if !isTerminal(os.Stdin) {
+		return nil
+	}
+	return func(confirm bool) (string, error) {
+		passphrase, err := promptSecret("Passphrase for " + credentialsFileLabel())
+		if err != nil || !confirm {
+			return passphrase, err
+		}
+		again, err := promptSecret("Repeat passphrase")
+		if err != nil {
+			return "", err
+		}
+		if again != passphrase {
+			return "", errors.New("passphrases do not match")
+		}
+		return passphrase, nil
+	}
+}
+
+// credentialsFileLabel names the encrypted credentials file in prompts
+func credentialsFileLabel() string {
+	if path, err := credentials.FilePath(); err == nil {
+		return path
+	}
+	return "the credentials file"
+}
+
+// prompt asks for a line of input on stderr, returning def if the answer is empty
+func prompt(label, def string) string {
+	if def != "" {
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s: ", label)
+	}
+	answer, _ := stdin.ReadString('\n')
+	if answer = strings.TrimSpace(answer); answer == "" {
+		return def
+	}
+	return answer
+}
+
+// promptSecret asks for a line of input without echoing it
+func promptSecret(label string) (string, error) {
+	fmt.Fprintf(os.Stderr, "%s: ", label)
+	secret, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(secret), nil
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
-	"errors"
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 
+	"github.com/rursache/loto-cli/analytics"
 	"github.com/rursache/loto-cli/client"
 	"github.com/rursache/loto-cli/config"
 	"github.com/rursache/loto-cli/models"
@@ -15,7 +18,23 @@ import (
 var version = "dev"
 
 func main() {
-	args := os.Args[1:]
+	var args []string
+	var err error
+	opts, args, err = parseGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if opts.profile == "" {
+		opts.profile = os.Getenv(config.EnvProfile)
+	}
+	if opts.profile != "" {
+		if err := config.UseProfile(opts.profile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitConfig)
+		}
+	}
 
 	if len(args) < 1 {
 		maybePromptSkillInstall()
@@ -31,13 +50,33 @@ func main() {
 	case "version", "--version", "-v":
 		fmt.Printf("loto-cli %s\n", version)
 	case "results":
-		runResults()
+		runResults(args[1:])
 	case "tickets":
-		withClient(runTickets)
+		runTicketsCmd(args[1:])
 	case "stats":
-		withClient(runStats)
+		runStatsCmd(args[1:])
+	case "sync":
+		withClient(runSync)
+	case "export":
+		runExportCmd(args[1:])
+	case "budget":
+		runBudgetCmd(args[1:])
+	case "analyze":
+		runAnalyzeCmd(args[1:])
+	case "check":
+		runCheckCmd(args[1:])
+	case "check-numbers":
+		runCheckNumbersCmd(args[1:])
+	case "jackpot":
+		runJackpotCmd(args[1:])
+	case "doctor":
+		runDoctorCmd(args[1:])
+	case "login":
+		runLoginCmd(args[1:])
+	case "profiles":
+		runProfilesCmd(args[1:])
 	case "config":
-		runConfig()
+		runConfigCmd(args[1:])
 	case "setup-skills":
 		runSetupSkills()
 	case "tui":
@@ -57,77 +96,119 @@ Usage:
   loto-cli [command]
 
 Commands:
-  results       Print latest extraction results
-  tickets       Print ticket history
-  stats         Print ticket statistics
-  config        Print config file path
+  results       Print latest extraction results, or archived draws with
+                --game/--date/--from/--to (see "results -h")
+  results import <file>
+                Import historical draws from a CSV or JSON file
+  tickets       Print ticket history (--lines to show played numbers)
+                --game, --status won|lost|pending, --from, --to,
+                --min-prize, --search <id>: filter the tickets
+                --sort date|price|prize (--reverse), --limit N
+  stats         Print ticket statistics (--all-profiles to combine every
+                profile's tickets, --period week|month|year to break
+                spending and winnings down per period)
+  sync          Update the local ticket archive from bilete.loto.ro
+  export        Export tickets as CSV, XLSX, OFX or JSON (--format, --out,
+                --summary for monthly totals, --game/--from/--to)
+  budget        Print spending against the daily, weekly and monthly limits
+                set in the config (--check exits 8 if one is exceeded)
+  check         Check played numbers against archived draws and flag
+                tickets whose site status looks wrong (--mismatches)
+  check-numbers Check hand-entered numbers (e.g. paper tickets) against
+                the latest or an archived draw (no auth required)
+  jackpot       Print next draw jackpots and winners per prize category
+  analyze       Number frequencies, hot/cold/overdue numbers, pairs,
+                triplets and distributions of archived draws (--game,
+                --from/--to, --last N, --top N; no auth required)
+  doctor        Check that loto.ro pages still match what the scrapers
+                expect (--dump <file> saves the raw HTML for bug reports)
+  login         Store the bilete.loto.ro password in the configured
+                credential backend (--backend to switch, see README)
+  profiles      List, add or remove account profiles
+                (profiles list, profiles add <name>, profiles remove <name>)
+  config        Print config file path, or manage the config file:
+                config init (interactive setup, checks the credentials),
+                config get [key], config set <key> <value>,
+                config validate, config edit (opens $EDITOR)
   setup-skills  Install AI skills for Claude Code and other agents
   tui           Start interactive TUI (default when no command)
 
 Options:
   help, -h        Show this help message
   version, -v     Show version
+  --format, -f    Output format for results, tickets, stats and checks:
+                  text (default), json or ndjson
+  --offline       Read tickets from the local archive (see "sync")
+                  instead of logging in
+  --profile, -p   Account profile to use (default: $LOTO_PROFILE or
+                  "default"), each with its own config, session and
+                  ticket archive
 
 Config:
   Default: ~/.config/loto-cli/config.json
+  Archive: ~/.config/loto-cli/archive.db
 
 `)
 }
 
-func runConfig() {
-	configPath, err := config.GetConfigPath()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+func runResults(args []string) {
+	if len(args) > 0 && args[0] == "import" {
+		runImportResults(args[1:])
+		return
 	}
-	fmt.Println(configPath)
-}
 
-func runResults() {
-	created, err := config.EnsureExists()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating config: %v\n", err)
-		os.Exit(1)
-	}
-	if created {
-		configPath, _ := config.GetConfigPath()
-		fmt.Fprintf(os.Stderr, "Config file created at: %s\n", configPath)
-		fmt.Fprintf(os.Stderr, "Please edit it with your credentials and try again.\n")
+	fs := flag.NewFlagSet("results", flag.ContinueOnError)
+	game := fs.String("game", "", "only show draws of this game (e.g. \"Loto 6/49\", 649, joker)")
+	date := fs.String("date", "", "only show draws on this date (DD.MM.YYYY)")
+	from := fs.String("from", "", "only show draws on or after this date (DD.MM.YYYY)")
+	to := fs.String("to", "", "only show draws on or before this date (DD.MM.YYYY)")
+	page := fs.Int("page", 1, "page of archived draws to show")
+	perPage := fs.Int("per-page", 20, "archived draws per page (0 shows all)")
+	if err := fs.Parse(args); err != nil {
 		os.Exit(1)
 	}
 
-	cfg, err := config.Load()
-	if err != nil {
-		if errors.Is(err, config.ErrCredentialsMissing) {
-			configPath, _ := config.GetConfigPath()
+	if *game != "" || *date != "" || *from != "" || *to != "" {
+		q, err := parseDrawQuery(*game, *date, *from, *to)
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			fmt.Fprintf(os.Stderr, "Please edit: %s\n", configPath)
 			os.Exit(1)
 		}
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
+		runResultsHistory(q, *page, *perPage)
+		return
 	}
 
-	c, err := client.New(cfg)
+	c := newPublicClient()
+	ctx, cancel := commandContext(c)
+	defer cancel()
+
+	results, err := c.GetResults(ctx)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
-		os.Exit(1)
+		fatal("Error fetching results", err)
 	}
 
-	results, err := c.GetResults()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching results: %v\n", err)
-		os.Exit(1)
+	if err := archiveResults(results); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to archive results: %v\n", err)
 	}
 
+	if structured() {
+		writeStructured("results", results)
+		return
+	}
+
+	printExtractions(results)
+}
+
+// printExtractions prints one section per extraction
+func printExtractions(results []models.Extraction) {
 	for i, ext := range results {
 		if i > 0 {
 			fmt.Println()
 		}
-		fmt.Printf("=== %s ===\n", ext.Game)
+		fmt.Printf("=== %s ===\n", ext.Label())
 		fmt.Printf("Date: %s\n", ext.Date)
-		// Noroc/Super Noroc numbers are individual digits that form a single number
-		if ext.Game == models.GameNoroc || ext.Game == models.GameSuperNoroc {
+		// Noroc numbers are individual digits that form a single number
+		if ext.Game.IsDigitGame() {
 			fmt.Printf("Number: %s\n", formatNorocNumber(ext.Numbers))
 		} else {
 			fmt.Printf("Numbers: %s\n", formatNumbers(ext.Numbers))
@@ -138,12 +219,85 @@ func runResults() {
 	}
 }
 
-func runTickets(c *client.Client) {
-	tickets, err := c.GetAllTickets()
+// runTicketsCmd parses the tickets command flags and runs it
+func runTicketsCmd(args []string) {
+	fs := flag.NewFlagSet("tickets", flag.ContinueOnError)
+	lines := fs.Bool("lines", false, "show the played numbers of every ticket (fetches each detail page when online)")
+	game := fs.String("game", "", "only show tickets of this game (e.g. \"Loto 6/49\", 649, joker)")
+	status := fs.String("status", "", "only show won, lost or pending tickets")
+	from := fs.String("from", "", "only show tickets for draws on or after this date (DD.MM.YYYY)")
+	to := fs.String("to", "", "only show tickets for draws on or before this date (DD.MM.YYYY)")
+	minPrize := fs.String("min-prize", "", "only show tickets that won at least this much (e.g. 100 or \"50,50 RON\")")
+	search := fs.String("search", "", "only show tickets whose ticket or order ID contains this text")
+	sort := fs.String("sort", "", "sort by date, price or prize, highest or newest first")
+	reverse := fs.Bool("reverse", false, "reverse the order (oldest or lowest first)")
+	limit := fs.Int("limit", 0, "show at most this many tickets (0 shows all)")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(1)
+	}
+
+	q, err := parseTicketQuery(*game, *status, *from, *to, *minPrize, *sort)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching tickets: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *limit < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --limit must not be negative")
 		os.Exit(1)
 	}
+	q.Search, q.Ascending, q.Limit = *search, *reverse, *limit
+
+	withTickets(*lines, func(tickets []models.Ticket) {
+		warnBudget(tickets)
+		runTickets(q.Apply(tickets), *lines)
+	})
+}
+
+// parseTicketQuery builds a ticket query from the tickets command flags
+func parseTicketQuery(game, status, from, to, minPrize, sort string) (models.TicketQuery, error) {
+	var q models.TicketQuery
+	var err error
+
+	if game != "" {
+		if q.Game, err = models.ParseGame(game); err != nil {
+			return q, err
+		}
+	}
+	if status != "" {
+		if q.Status = models.ParseTicketStatus(strings.ToLower(status)); q.Status == models.StatusUnknown {
+			return q, fmt.Errorf("unknown status %q (expected won, lost or pending)", status)
+		}
+	}
+	if from != "" {
+		if q.From, err = models.ParseDrawDate(from); err != nil {
+			return q, fmt.Errorf("--from: %w", err)
+		}
+	}
+	if to != "" {
+		if q.To, err = models.ParseDrawDate(to); err != nil {
+			return q, fmt.Errorf("--to: %w", err)
+		}
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return q, fmt.Errorf("--to is before --from")
+	}
+	if minPrize != "" {
+		if q.MinPrize, err = models.ParseMoney(minPrize); err != nil {
+			return q, fmt.Errorf("--min-prize: %w", err)
+		}
+	}
+	if q.Sort, err = models.ParseTicketSort(sort); err != nil {
+		return q, err
+	}
+
+	return q, nil
+}
+
+func runTickets(tickets []models.Ticket, showLines bool) {
+	if structured() {
+		writeStructured("tickets", tickets)
+		return
+	}
 
 	if len(tickets) == 0 {
 		fmt.Println("No tickets found.")
@@ -166,147 +320,202 @@ func runTickets(c *client.Client) {
 			t.Price,
 			prize,
 		)
+		if showLines {
+			printPlayedLines(t)
+		}
 	}
 
 	fmt.Println(strings.Repeat("-", 80))
 	fmt.Printf("Total: %d ticket(s)\n", len(tickets))
 }
 
-func runStats(c *client.Client) {
-	tickets, err := c.GetAllTickets()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching tickets: %v\n", err)
+// runStatsCmd parses the stats command flags and runs it
+func runStatsCmd(args []string) {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	allProfiles := fs.Bool("all-profiles", false, "combine the tickets of every profile")
+	period := fs.String("period", "", "also break spending and winnings down by week, month or year")
+	if err := fs.Parse(args); err != nil {
 		os.Exit(1)
 	}
 
-	if len(tickets) == 0 {
-		fmt.Println("No tickets found.")
+	// JSON output always has the breakdown, by month unless --period says otherwise
+	p := analytics.Month
+	if *period != "" {
+		var err error
+		if p, err = analytics.ParsePeriod(*period); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	showBuckets := *period != ""
+
+	if *allProfiles {
+		runAllProfilesStats(p, showBuckets)
 		return
 	}
+	withTickets(false, func(tickets []models.Ticket) {
+		warnBudget(tickets)
+		runStats(tickets, p, showBuckets)
+	})
+}
 
-	totalTickets := len(tickets)
-	var totalSpent, totalWon float64
-	var wonCount, lostCount, pendingCount int
-	gameCount := make(map[models.Game]int)
-	gameSpent := make(map[models.Game]float64)
-	gameWon := make(map[models.Game]int)
-	gameWonAmount := make(map[models.Game]float64)
+// statsReport is the output of "stats": the overview and its analytics
+type statsReport struct {
+	models.Stats
+	Analytics analytics.Report `json:"analytics"`
+}
 
-	for _, t := range tickets {
-		price := parsePriceStr(t.Price)
-		totalSpent += price
-		gameCount[t.Game]++
-		gameSpent[t.Game] += price
-
-		switch t.Status {
-		case models.StatusWon:
-			wonCount++
-			gameWon[t.Game]++
-			if t.Prize != "" {
-				gameWonAmount[t.Game] += parsePriceStr(t.Prize)
-				totalWon += parsePriceStr(t.Prize)
-			}
-		case models.StatusLost:
-			lostCount++
-		case models.StatusPending:
-			pendingCount++
-		}
-	}
+func runStats(tickets []models.Ticket, period analytics.Period, showBuckets bool) {
+	report := statsReport{Stats: models.ComputeStats(tickets), Analytics: analytics.Compute(tickets, period)}
 
-	firstDate := tickets[len(tickets)-1].DrawDate
-	lastDate := tickets[0].DrawDate
-	avgPrice := totalSpent / float64(totalTickets)
-	netResult := totalWon - totalSpent
+	if structured() {
+		writeStructured("stats", report)
+		return
+	}
 
-	winRate := float64(0)
-	if decided := wonCount + lostCount; decided > 0 {
-		winRate = float64(wonCount) / float64(decided) * 100
+	if len(tickets) == 0 {
+		fmt.Println("No tickets found.")
+		return
 	}
+	printStats(report.Stats)
+	printAnalytics(report.Analytics, showBuckets)
+}
 
+// printStats prints the overview, results and per-game sections of the statistics
+func printStats(stats models.Stats) {
 	fmt.Println("=== Overview ===")
-	fmt.Printf("  Total Tickets:    %d\n", totalTickets)
-	fmt.Printf("  Total Spent:      %.2f RON\n", totalSpent)
-	fmt.Printf("  Total Won:        %.2f RON\n", totalWon)
-	fmt.Printf("  Net Result:       %+.2f RON\n", netResult)
-	fmt.Printf("  Avg Ticket Price: %.2f RON\n", avgPrice)
-	fmt.Printf("  Date Range:       %s → %s\n", firstDate, lastDate)
+	fmt.Printf("  Total Tickets:    %d\n", stats.TotalTickets)
+	fmt.Printf("  Total Spent:      %.2f RON\n", stats.TotalSpent)
+	fmt.Printf("  Total Won:        %.2f RON\n", stats.TotalWon)
+	fmt.Printf("  Net Result:       %+.2f RON\n", stats.NetResult)
+	fmt.Printf("  Avg Ticket Price: %.2f RON\n", stats.AvgTicketPrice)
+	fmt.Printf("  Date Range:       %s → %s\n", stats.FirstDrawDate, stats.LastDrawDate)
 
 	fmt.Println()
 	fmt.Println("=== Results ===")
-	fmt.Printf("  Won:      %d\n", wonCount)
-	fmt.Printf("  Lost:     %d\n", lostCount)
-	if pendingCount > 0 {
-		fmt.Printf("  Pending:  %d\n", pendingCount)
+	fmt.Printf("  Won:      %d\n", stats.Won)
+	fmt.Printf("  Lost:     %d\n", stats.Lost)
+	if stats.Pending > 0 {
+		fmt.Printf("  Pending:  %d\n", stats.Pending)
 	}
-	fmt.Printf("  Win Rate: %.1f%%\n", winRate)
+	fmt.Printf("  Win Rate: %.1f%%\n", stats.WinRate)
 
 	fmt.Println()
 	fmt.Println("=== By Game ===")
-	for _, g := range []models.Game{models.GameLoto649, models.GameLoto540, models.GameJoker} {
-		count := gameCount[g]
-		if count == 0 {
-			continue
-		}
-		fmt.Printf("  %s\n", g)
+	for _, gs := range stats.ByGame {
+		fmt.Printf("  %s\n", gs.Game)
 		fmt.Printf("    Tickets: %d  |  Spent: %.2f RON  |  Won: %d (%.2f RON)\n",
-			count, gameSpent[g], gameWon[g], gameWonAmount[g])
+			gs.Tickets, gs.Spent, gs.Won, gs.WonAmount)
+	}
+}
+
+// printAnalytics prints the highlights of an analytics report and, with showBuckets,
+// its breakdown by period
+func printAnalytics(r analytics.Report, showBuckets bool) {
+	fmt.Println()
+	fmt.Println("=== Highlights ===")
+	fmt.Printf("  Return on Spend:   %.1f%%\n", r.ReturnOnSpend)
+	fmt.Printf("  Avg per Draw:      %s (%d draws)\n", ron(r.AvgSpendPerDraw), r.Draws)
+	if r.BiggestWin != nil {
+		fmt.Printf("  Biggest Win:       %s (%s, %s)\n", ron(r.BiggestWin.Prize), r.BiggestWin.Game, r.BiggestWin.DrawDate)
+	}
+	fmt.Printf("  Losing Streak:     %d longest, %d current\n", r.LongestLosingStreak, r.CurrentLosingStreak)
+
+	if !showBuckets {
+		return
+	}
+	fmt.Println()
+	fmt.Printf("=== By %s ===\n", strings.ToUpper(string(r.Period[:1]))+string(r.Period[1:]))
+	fmt.Printf("  %-10s %7s %5s %14s %14s %14s %14s\n", "Period", "Tickets", "Won", "Spent", "Won", "Net", "Cumulative")
+	for _, b := range r.Buckets {
+		fmt.Printf("  %-10s %7d %5d %14s %14s %14s %14s\n",
+			b.Key, b.Tickets, b.Won, ron(b.Spent), ron(b.WonAmount), signed(b.Net), signed(b.CumulativeNet))
 	}
 }
 
-// parsePriceStr extracts a float from "24,50 RON" format
-func parsePriceStr(s string) float64 {
-	s = strings.TrimSpace(s)
-	s = strings.TrimSuffix(s, " RON")
-	s = strings.TrimSuffix(s, "RON")
-	s = strings.TrimSpace(s)
-	s = strings.ReplaceAll(s, ",", ".")
-	var v float64
-	fmt.Sscanf(s, "%f", &v)
-	return v
+// ron formats an amount the way printStats does
+func ron(m models.Money) string {
+	return fmt.Sprintf("%.2f RON", m.Float())
+}
+
+// signed formats a net result with an explicit sign
+func signed(m models.Money) string {
+	if m.Amount > 0 {
+		return "+" + ron(m)
+	}
+	return ron(m)
 }
 
 func runTUI() {
-	created, err := config.EnsureExists()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating config: %v\n", err)
-		os.Exit(1)
+	// Offline, only results are fetched live and no credentials are needed
+	var c *client.Client
+	if opts.offline {
+		c = newPublicClient()
+	} else {
+		c = newAuthClient()
 	}
-	if created {
-		configPath, _ := config.GetConfigPath()
-		fmt.Fprintf(os.Stderr, "Config file created at: %s\n", configPath)
-		fmt.Fprintf(os.Stderr, "Please edit it with your credentials and try again.\n")
-		os.Exit(1)
+
+	src := tui.Sources{
+		Results: func(ctx context.Context) ([]models.Extraction, error) {
+			results, err := c.GetResults(ctx)
+			if err == nil {
+				archiveResults(results) // best effort, the TUI owns the terminal
+			}
+			return results, err
+		},
+		Jackpots: c.GetPrizeReports,
+		Tickets: func(context.Context, func(string, int, int)) ([]models.Ticket, error) {
+			return loadArchivedTickets()
+		},
+		Draws:   loadArchivedDraws,
+		Budget:  budgetLimits(c.Config),
+		Timeout: c.Config.TimeoutDuration(),
 	}
 
-	cfg, err := config.Load()
-	if err != nil {
-		if errors.Is(err, config.ErrCredentialsMissing) {
-			configPath, _ := config.GetConfigPath()
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			fmt.Fprintf(os.Stderr, "Please edit: %s\n", configPath)
-			os.Exit(1)
+	if !opts.offline {
+		ctx, cancel := commandContext(c)
+		fmt.Fprintln(os.Stderr, "Logging in to loto.ro...")
+		if err := c.Login(ctx); err != nil {
+			fatal("Login error", err)
+		}
+		cancel()
+		src.Tickets = func(ctx context.Context, progress func(stage string, done, total int)) ([]models.Ticket, error) {
+			return c.GetAllTickets(ctx, progress)
 		}
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
 	}
 
-	c, err := client.New(cfg)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
-		os.Exit(1)
-	}
+	// Each load is bounded by the overall timeout; the TUI cancels it on quit or refresh
+	tui.Run(context.Background(), src)
+}
+
+// withClient handles config loading, client creation, login, and runs a command
+func withClient(fn func(context.Context, *client.Client)) {
+	c := newAuthClient()
+	ctx, cancel := commandContext(c)
+	defer cancel()
 
 	fmt.Fprintln(os.Stderr, "Logging in to loto.ro...")
-	if err := c.Login(); err != nil {
-		fmt.Fprintf(os.Stderr, "Login error: %v\n", err)
-		os.Exit(1)
+	if err := c.Login(ctx); err != nil {
+		fatal("Login error", err)
 	}
 
-	tui.Run(c)
+	fn(ctx, c)
 }
 
-// withClient handles config loading, client creation, login, and runs a command
-func withClient(fn func(*client.Client)) {
+// commandContext returns the context for a command's requests. It is cancelled
+// on Ctrl+C and when the configured overall timeout expires.
+func commandContext(c *client.Client) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Config.TimeoutDuration())
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// newAuthClient creates a client for commands that log in.
+// The config file is created on first run; the password comes from the configured backend.
+func newAuthClient() *client.Client {
 	created, err := config.EnsureExists()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating config: %v\n", err)
@@ -316,34 +525,62 @@ func withClient(fn func(*client.Client)) {
 		configPath, _ := config.GetConfigPath()
 		fmt.Fprintf(os.Stderr, "Config file created at: %s\n", configPath)
 		fmt.Fprintf(os.Stderr, "Please edit it with your credentials and try again.\n")
-		os.Exit(1)
+		os.Exit(exitConfig)
 	}
 
 	cfg, err := config.Load()
 	if err != nil {
-		if errors.Is(err, config.ErrCredentialsMissing) {
-			configPath, _ := config.GetConfigPath()
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			fmt.Fprintf(os.Stderr, "Please edit: %s\n", configPath)
-			os.Exit(1)
-		}
-		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
+		fatal("Error loading config", err)
 	}
+	resolveCredentials(cfg)
+
+	return mustNewClient(cfg)
+}
 
+// newPublicClient creates a client for commands that only read public loto.ro pages.
+// The config file is optional (user agent, proxy) and credentials are not needed.
+func newPublicClient() *client.Client {
+	cfg, err := config.LoadOptional()
+	if err != nil {
+		fatal("Error loading config", err)
+	}
+
+	return mustNewClient(cfg)
+}
+
+// mustNewClient creates a client or exits
+func mustNewClient(cfg *config.Config) *client.Client {
 	c, err := client.New(cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
 		os.Exit(1)
 	}
+	return c
+}
 
-	fmt.Fprintln(os.Stderr, "Logging in to loto.ro...")
-	if err := c.Login(); err != nil {
-		fmt.Fprintf(os.Stderr, "Login error: %v\n", err)
-		os.Exit(1)
+// printPlayedLines prints the played variants and Noroc numbers of a ticket, indented under its row
+func printPlayedLines(t models.Ticket) {
+	if len(t.Lines) == 0 && len(t.Noroc) == 0 {
+		fmt.Println("    (no played numbers available)")
+		return
+	}
+	for i, l := range t.Lines {
+		numbers := formatNumbers(l.Numbers)
+		if l.Joker > 0 {
+			numbers += fmt.Sprintf(" + %d", l.Joker)
+		}
+		result := ""
+		if l.Category != "" {
+			result = "Cat. " + l.Category
+		}
+		if l.Prize != "" {
+			result = strings.TrimSpace(result + "  " + l.Prize)
+		}
+		fmt.Printf("    %2d. %-28s %s\n", i+1, numbers, result)
+	}
+	for _, n := range t.Noroc {
+		fmt.Printf("    %s: %s\n", n.Game, n.Number)
 	}
-
-	fn(c)
 }
 
 // formatNumbers formats a slice of ints as space-separated strings
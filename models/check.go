@@ -0,0 +1,226 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LineCheck is the result of matching a played line against a draw
+type LineCheck struct {
+	Line     PlayedLine    `json:"line"`
+	Hits     []int         `json:"hits"`
+	JokerHit bool          `json:"joker_hit,omitempty"`
+	Category string        `json:"category,omitempty"` // best category won, empty if none
+	Wins     []CategoryWin `json:"wins,omitempty"`     // every category won, more than one for combined variants
+}
+
+// CategoryWin counts the winning combinations of a single category
+type CategoryWin struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+// NorocCheck is the result of matching a Noroc number against a draw
+type NorocCheck struct {
+	Entry    NorocEntry `json:"entry"`
+	Drawn    string     `json:"drawn"`
+	Matched  int        `json:"matched"`            // digits matched in order from the left or right, whichever is more
+	Category string     `json:"category,omitempty"` // empty if none
+}
+
+// TicketCheck is the outcome of checking every line and Noroc number of a ticket
+type TicketCheck struct {
+	Lines   []LineCheck  `json:"lines"`
+	Noroc   []NorocCheck `json:"noroc,omitempty"`
+	Won     bool         `json:"won"`
+	Checked bool         `json:"checked"`          // false if the draw or played numbers are unknown
+	Reason  string       `json:"reason,omitempty"` // why the ticket could not be checked
+}
+
+// Mismatch reports whether the computed result disagrees with the status (or line
+// categories) reported by the site. Unchecked and pending tickets never mismatch.
+func (c TicketCheck) Mismatch(status TicketStatus) bool {
+	if !c.Checked {
+		return false
+	}
+	for _, lc := range c.Lines {
+		if lc.Line.Category != "" && lc.Line.Category != lc.Category {
+			return true
+		}
+	}
+	switch status {
+	case StatusWon:
+		return !c.Won
+	case StatusLost:
+		return c.Won
+	default:
+		return false
+	}
+}
+
+// CheckLine matches a played line against a draw of the same game, whose numbers
+// are in the order they were drawn. Lines with more numbers than the game's Pick
+// are combined variants: every Pick-sized combination is a separate variant and
+// wins at most one category, its best.
+func CheckLine(rules GameRules, line PlayedLine, draw Extraction) LineCheck {
+	res := LineCheck{Line: line, Hits: []int{}}
+
+	// Position of each number in the draw
+	drawn := make(map[int]int, len(draw.Numbers))
+	for i, n := range draw.Numbers {
+		drawn[n] = i
+	}
+	first := rules.firstDrawn()
+	early, late := 0, 0 // hits among the first numbers drawn and among the rest
+	for _, n := range line.Numbers {
+		i, ok := drawn[n]
+		if !ok {
+			continue
+		}
+		res.Hits = append(res.Hits, n)
+		if i < first {
+			early++
+		} else {
+			late++
+		}
+	}
+
+	if rules.JokerMax > 0 && len(draw.Bonus) > 0 {
+		res.JokerHit = line.Joker == draw.Bonus[0]
+	}
+
+	// Count the variants by how many early and late hits they hold
+	misses := len(line.Numbers) - early - late
+	counts := make(map[string]int)
+	for e := 0; e <= min(early, rules.Pick); e++ {
+		for l := 0; l <= min(late, rules.Pick-e); l++ {
+			count := binomial(early, e) * binomial(late, l) * binomial(misses, rules.Pick-e-l)
+			if count == 0 {
+				continue
+			}
+			if cat, ok := rules.category(e+l, e, res.JokerHit); ok {
+				counts[cat.Name] += count
+			}
+		}
+	}
+
+	for _, cat := range rules.Categories {
+		if counts[cat.Name] == 0 {
+			continue
+		}
+		res.Wins = append(res.Wins, CategoryWin{Category: cat.Name, Count: counts[cat.Name]})
+		if res.Category == "" {
+			res.Category = cat.Name
+		}
+	}
+
+	return res
+}
+
+// CheckNoroc matches a Noroc number against a draw of the same Noroc game.
+// A prize is won by matching digits in order from the first or from the last digit.
+func CheckNoroc(rules DigitRules, entry NorocEntry, draw Extraction) NorocCheck {
+	var drawn strings.Builder
+	for _, d := range draw.Numbers {
+		fmt.Fprintf(&drawn, "%d", d)
+	}
+	res := NorocCheck{Entry: entry, Drawn: drawn.String()}
+
+	played, target := entry.Number, res.Drawn
+	if len(played) != len(target) {
+		return res
+	}
+
+	leading := 0
+	for leading < len(played) && played[leading] == target[leading] {
+		leading++
+	}
+	trailing := 0
+	for trailing < len(played) && played[len(played)-1-trailing] == target[len(target)-1-trailing] {
+		trailing++
+	}
+	res.Matched = max(leading, trailing)
+
+	for _, cat := range rules.Categories {
+		if res.Matched >= cat.Hits {
+			res.Category = cat.Name
+			break
+		}
+	}
+
+	return res
+}
+
+// CheckTicket matches a ticket's played lines and Noroc numbers against the draws
+// held for its game and draw date.
+func CheckTicket(t Ticket, draws []Extraction) TicketCheck {
+	var res TicketCheck
+
+	rules, ok := RulesFor(t.Game)
+	if !ok {
+		res.Reason = fmt.Sprintf("unsupported game %q", t.Game)
+		return res
+	}
+	if len(t.Lines) == 0 {
+		res.Reason = "played numbers unknown"
+		return res
+	}
+
+	draw, ok := FindDraw(draws, t.Game, t.DrawDate)
+	if !ok {
+		res.Reason = "draw not archived"
+		return res
+	}
+
+	res.Checked = true
+	for _, line := range t.Lines {
+		lc := CheckLine(rules, line, draw)
+		res.Won = res.Won || lc.Category != ""
+		res.Lines = append(res.Lines, lc)
+	}
+
+	for _, entry := range t.Noroc {
+		dr, ok := DigitRulesFor(entry.Game)
+		if !ok {
+			continue
+		}
+		nd, ok := FindDraw(draws, entry.Game, t.DrawDate)
+		if !ok {
+			continue
+		}
+		nc := CheckNoroc(dr, entry, nd)
+		res.Won = res.Won || nc.Category != ""
+		res.Noroc = append(res.Noroc, nc)
+	}
+
+	return res
+}
+
+// FindDraw returns the main draw of a game on the given date (in any supported date format)
+func FindDraw(draws []Extraction, game Game, date string) (Extraction, bool) {
+	day, err := ParseDrawDate(date)
+	if err != nil {
+		return Extraction{}, false
+	}
+	for _, d := range draws {
+		if d.Game != game || d.Draw != 0 {
+			continue
+		}
+		if dd, err := ParseDrawDate(d.Date); err == nil && dd.Equal(day) {
+			return d, true
+		}
+	}
+	return Extraction{}, false
+}
+
+// binomial returns n choose k, or 0 when k is out of range
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCheckLine(t *testing.T) {
+	loto649 := Extraction{Game: GameLoto649, Numbers: []int{3, 14, 25, 36, 47, 8}}
+	// Loto 5/40 category I needs the first five numbers drawn, 17 is the sixth
+	loto540 := Extraction{Game: GameLoto540, Numbers: []int{10, 20, 30, 40, 5, 17}}
+	joker := Extraction{Game: GameJoker, Numbers: []int{1, 2, 3, 4, 5}, Bonus: []int{7}}
+
+	tests := []struct {
+		name     string
+		draw     Extraction
+		numbers  []int
+		joker    int
+		category string
+		wins     string
+	}{
+		{"6/49 six hits", loto649, []int{8, 3, 14, 25, 36, 47}, 0, "I", "[{I 1}]"},
+		{"6/49 five hits", loto649, []int{3, 14, 25, 36, 47, 1}, 0, "II", "[{II 1}]"},
+		{"6/49 four hits", loto649, []int{3, 14, 25, 36, 1, 2}, 0, "III", "[{III 1}]"},
+		{"6/49 three hits", loto649, []int{3, 14, 25, 1, 2, 4}, 0, "IV", "[{IV 1}]"},
+		{"6/49 two hits", loto649, []int{3, 14, 1, 2, 4, 5}, 0, "", "[]"},
+		// 28 variants: 1 with six hits, 6×2 with five, 15 with four
+		{"6/49 combined 8 numbers", loto649, []int{3, 14, 25, 36, 47, 8, 1, 2}, 0, "I", "[{I 1} {II 12} {III 15}]"},
+		// 7 variants, 4 of them with the three hits
+		{"6/49 combined 7 numbers", loto649, []int{3, 14, 25, 1, 2, 4, 5}, 0, "IV", "[{IV 4}]"},
+
+		{"5/40 all six drawn", loto540, []int{10, 20, 30, 40, 5, 17}, 0, "I", "[{I 1}]"},
+		{"5/40 first five drawn", loto540, []int{10, 20, 30, 40, 5, 33}, 0, "I", "[{I 1}]"},
+		{"5/40 five of six drawn", loto540, []int{10, 20, 30, 40, 17, 33}, 0, "II", "[{II 1}]"},
+		{"5/40 four of six drawn", loto540, []int{10, 20, 30, 17, 1, 2}, 0, "III", "[{III 1}]"},
+		{"5/40 three of six drawn", loto540, []int{10, 20, 17, 1, 2, 3}, 0, "IV", "[{IV 1}]"},
+		{"5/40 sixth drawn first", Extraction{Game: GameLoto540, Numbers: []int{17, 10, 20, 30, 40, 5}},
+			[]int{10, 20, 30, 40, 5, 33}, 0, "II", "[{II 1}]"},
+		// 7 variants: 2 hold the first five drawn, the other 5 hold four of them and 17
+		{"5/40 combined 7 numbers", loto540, []int{10, 20, 30, 40, 5, 17, 33}, 0, "I", "[{I 2} {II 5}]"},
+
+		{"Joker 5+1", joker, []int{1, 2, 3, 4, 5}, 7, "I", "[{I 1}]"},
+		{"Joker 5", joker, []int{1, 2, 3, 4, 5}, 8, "II", "[{II 1}]"},
+		{"Joker 4+1", joker, []int{1, 2, 3, 4, 40}, 7, "III", "[{III 1}]"},
+		{"Joker 4", joker, []int{1, 2, 3, 4, 40}, 8, "IV", "[{IV 1}]"},
+		{"Joker 3+1", joker, []int{1, 2, 3, 40, 41}, 7, "V", "[{V 1}]"},
+		{"Joker 3", joker, []int{1, 2, 3, 40, 41}, 8, "VI", "[{VI 1}]"},
+		{"Joker 2+1", joker, []int{1, 2, 40, 41, 42}, 7, "VII", "[{VII 1}]"},
+		{"Joker 2", joker, []int{1, 2, 40, 41, 42}, 8, "", "[]"},
+		{"Joker 1+1", joker, []int{1, 40, 41, 42, 43}, 7, "VIII", "[{VIII 1}]"},
+		{"Joker 0+1", joker, []int{40, 41, 42, 43, 44}, 7, "", "[]"},
+		// 6 variants: 1 with the five hits, 5 with four
+		{"Joker combined 6 numbers", joker, []int{1, 2, 3, 4, 5, 40}, 7, "I", "[{I 1} {III 5}]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, _ := RulesFor(tt.draw.Game)
+			lc := CheckLine(rules, PlayedLine{Numbers: tt.numbers, Joker: tt.joker}, tt.draw)
+			if lc.Category != tt.category {
+				t.Errorf("category = %q, want %q", lc.Category, tt.category)
+			}
+			if got := fmt.Sprint(lc.Wins); got != tt.wins {
+				t.Errorf("wins = %s, want %s", got, tt.wins)
+			}
+		})
+	}
+}
+
+func TestCheckLineHits(t *testing.T) {
+	rules, _ := RulesFor(GameJoker)
+	draw := Extraction{Game: GameJoker, Numbers: []int{1, 2, 3, 4, 5}, Bonus: []int{7}}
+	lc := CheckLine(rules, PlayedLine{Numbers: []int{5, 40, 1, 41, 42}, Joker: 7}, draw)
+	if fmt.Sprint(lc.Hits) != "[5 1]" || !lc.JokerHit {
+		t.Errorf("hits = %v, Joker hit %v; want [5 1] and the Joker", lc.Hits, lc.JokerHit)
+	}
+}
+
+func TestCheckNoroc(t *testing.T) {
+	noroc := Extraction{Game: GameNoroc, Numbers: []int{1, 2, 3, 4, 5, 6, 7}}
+	superNoroc := Extraction{Game: GameSuperNoroc, Numbers: []int{4, 0, 2, 9, 1, 8}}
+	norocPlus := Extraction{Game: GameNorocPlus, Numbers: []int{5, 5, 1, 2, 3, 4}}
+
+	tests := []struct {
+		draw     Extraction
+		number   string
+		matched  int
+		category string
+	}{
+		{noroc, "1234567", 7, "I"},
+		{noroc, "1234560", 6, "II"},
+		{noroc, "1234500", 5, "III"},
+		{noroc, "9994567", 4, "IV"},
+		{noroc, "1239999", 3, "V"},
+		{noroc, "1299967", 2, "VI"}, // two leading and two trailing digits
+		{noroc, "9999990", 0, ""},
+		{noroc, "123", 0, ""}, // wrong length
+
+		{superNoroc, "402918", 6, "I"},
+		{superNoroc, "402910", 5, "II"},
+		{superNoroc, "000918", 3, "IV"},
+		{superNoroc, "402000", 3, "IV"},
+		{superNoroc, "400000", 2, ""},
+
+		{norocPlus, "551234", 6, "I"},
+		{norocPlus, "551000", 3, "IV"},
+		{norocPlus, "900004", 1, "VI"},
+		{norocPlus, "500000", 1, "VI"},
+		{norocPlus, "123456", 0, ""},
+	}
+
+	for _, tt := range tests {
+		rules, _ := DigitRulesFor(tt.draw.Game)
+		nc := CheckNoroc(rules, NorocEntry{Game: tt.draw.Game, Number: tt.number}, tt.draw)
+		if nc.Matched != tt.matched || nc.Category != tt.category {
+			t.Errorf("%s %s: matched %d, category %q; want %d, %q", tt.draw.Game, tt.number, nc.Matched, nc.Category, tt.matched, tt.category)
+		}
+	}
+}
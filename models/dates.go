@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// drawDateLayouts are the date formats used for draw dates across loto.ro and bilete.loto.ro
+var drawDateLayouts = []string{
+	"02-01-2006", // loto.ro results
+	"02.01.2006", // bilete.loto.ro tickets
+	"2006-01-02", // ISO, used by the archive and imports
+}
+
+// ParseDrawDate parses a draw date in DD-MM-YYYY, DD.MM.YYYY or YYYY-MM-DD format
+func ParseDrawDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range drawDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (expected DD.MM.YYYY)", s)
+}
+
+// romanianMonths maps Romanian month names and abbreviations (without diacritics) to months
+var romanianMonths = map[string]time.Month{
+	"ian": time.January, "ianuarie": time.January,
+	"feb": time.February, "februarie": time.February,
+	"mar": time.March, "martie": time.March,
+	"apr": time.April, "aprilie": time.April,
+	"mai": time.May,
+	"iun": time.June, "iunie": time.June,
+	"iul": time.July, "iulie": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "septembrie": time.September,
+	"oct": time.October, "octombrie": time.October,
+	"noi": time.November, "nov": time.November, "noiembrie": time.November,
+	"dec": time.December, "decembrie": time.December,
+}
+
+// romanianWeekdays maps Romanian weekday names and abbreviations (without diacritics) to weekdays
+var romanianWeekdays = map[string]time.Weekday{
+	"lu": time.Monday, "luni": time.Monday,
+	"ma": time.Tuesday, "marti": time.Tuesday,
+	"mi": time.Wednesday, "miercuri": time.Wednesday,
+	"jo": time.Thursday, "joi": time.Thursday,
+	"vi": time.Friday, "vineri": time.Friday,
+	"sa": time.Saturday, "sambata": time.Saturday,
+	"du": time.Sunday, "duminica": time.Sunday,
+}
+
+// stripDiacritics replaces Romanian letters with diacritics by their base letters
+var stripDiacritics = strings.NewReplacer(
+	"ă", "a", "â", "a", "î", "i", "ș", "s", "ş", "s", "ț", "t", "ţ", "t",
+	"Ă", "A", "Â", "A", "Î", "I", "Ș", "S", "Ş", "S", "Ț", "T", "Ţ", "T",
+)
+
+// RomanianMonth returns the month named by a Romanian month name or abbreviation, e.g. "feb" or "martie"
+func RomanianMonth(name string) (time.Month, bool) {
+	m, ok := romanianMonths[strings.TrimSuffix(strings.ToLower(stripDiacritics.Replace(name)), ".")]
+	return m, ok
+}
+
+// RomanianWeekday returns the weekday named by a Romanian weekday name or abbreviation, e.g. "Jo" or "sâmbătă"
+func RomanianWeekday(name string) (time.Weekday, bool) {
+	d, ok := romanianWeekdays[strings.TrimSuffix(strings.ToLower(stripDiacritics.Replace(name)), ".")]
+	return d, ok
+}
+
+// ParseRomanianTime parses a date written with a Romanian month name and an
+// optional weekday and time, e.g. "Jo 12 feb 2026, Ora 18:58" or "12 februarie 2026".
+// A weekday that doesn't match the date is an error.
+func ParseRomanianTime(s string) (time.Time, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' })
+
+	var day, year, hour, minute int
+	var month time.Month
+	weekday := time.Weekday(-1)
+	for _, f := range fields {
+		if m, ok := RomanianMonth(f); ok && month == 0 {
+			month = m
+			continue
+		}
+		if d, ok := RomanianWeekday(f); ok && day == 0 {
+			weekday = d
+			continue
+		}
+		if h, mm, ok := strings.Cut(f, ":"); ok {
+			fmt.Sscanf(h+" "+mm, "%d %d", &hour, &minute)
+			continue
+		}
+		var n int
+		if _, err := fmt.Sscanf(f, "%d", &n); err != nil {
+			continue // "Ora", "Jucat"...
+		}
+		if day == 0 {
+			day = n
+		} else if year == 0 {
+			year = n
+		}
+	}
+
+	if day == 0 || month == 0 || year == 0 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	t := time.Date(year, month, day, hour, minute, 0, 0, time.Local)
+	if t.Day() != day || hour > 23 || minute > 59 {
+		return time.Time{}, fmt.Errorf("invalid date %q", s)
+	}
+	if weekday >= 0 && t.Weekday() != weekday {
+		return time.Time{}, fmt.Errorf("invalid date %q: %s is not a %s", s, t.Format("02.01.2006"), weekday)
+	}
+	return t, nil
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRomanianTime(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"Jo 12 feb 2026, Ora 18:58", time.Date(2026, time.February, 12, 18, 58, 0, 0, time.Local)},
+		{"Jucat Du 1 mar 2026, Ora 09:05", time.Date(2026, time.March, 1, 9, 5, 0, 0, time.Local)},
+		{"12 februarie 2026", time.Date(2026, time.February, 12, 0, 0, 0, 0, time.Local)},
+		{"sâmbătă, 14 noiembrie 2026", time.Date(2026, time.November, 14, 0, 0, 0, 0, time.Local)},
+		{"Mi 30 Sept. 2026", time.Date(2026, time.September, 30, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := ParseRomanianTime(tt.in)
+		if err != nil {
+			t.Errorf("ParseRomanianTime(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseRomanianTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{
+		"",
+		"12 2026",                   // no month
+		"feb 2026",                  // no day
+		"31 feb 2026",               // no such day
+		"Lu 12 feb 2026",            // 12.02.2026 is a Thursday
+		"Jo 12 feb 2026, Ora 25:00", // no such hour
+	} {
+		if got, err := ParseRomanianTime(in); err == nil {
+			t.Errorf("ParseRomanianTime(%q) = %v, want an error", in, got)
+		}
+	}
+}
+
+func TestParseDrawDate(t *testing.T) {
+	want := time.Date(2026, time.February, 12, 0, 0, 0, 0, time.Local)
+	for _, in := range []string{"12-02-2026", "12.02.2026", "2026-02-12", " 12.02.2026 "} {
+		if got, err := ParseDrawDate(in); err != nil || !got.Equal(want) {
+			t.Errorf("ParseDrawDate(%q) = %v, %v, want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseDrawDate("12/02/2026"); err == nil {
+		t.Error("ParseDrawDate accepted 12/02/2026")
+	}
+}
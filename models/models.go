@@ -1,35 +1,145 @@
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
 // Game represents a lottery game type
 type Game string
 
 const (
-	GameLoto649   Game = "Loto 6/49"
-	GameLoto540   Game = "Loto 5/40"
-	GameJoker     Game = "Joker"
-	GameNoroc     Game = "Noroc"
+	GameLoto649    Game = "Loto 6/49"
+	GameLoto540    Game = "Loto 5/40"
+	GameJoker      Game = "Joker"
+	GameNoroc      Game = "Noroc"
 	GameSuperNoroc Game = "Super Noroc"
+	GameNorocPlus  Game = "Noroc Plus"
 )
 
+// AllGames lists every known game in display order
+var AllGames = []Game{GameLoto649, GameLoto540, GameJoker, GameNoroc, GameSuperNoroc, GameNorocPlus}
+
+// ParseGame maps a user-supplied game name to a Game.
+// It accepts the display name in any case as well as short aliases like "649", "540" or "super-noroc".
+func ParseGame(name string) (Game, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	switch key {
+	case "649", "6/49", "loto649", "loto-649":
+		return GameLoto649, nil
+	case "540", "5/40", "loto540", "loto-540":
+		return GameLoto540, nil
+	case "super-noroc", "supernoroc":
+		return GameSuperNoroc, nil
+	case "noroc-plus", "norocplus":
+		return GameNorocPlus, nil
+	}
+	for _, g := range AllGames {
+		if strings.ToLower(string(g)) == key {
+			return g, nil
+		}
+	}
+	return "", fmt.Errorf("unknown game %q (expected one of: Loto 6/49, Loto 5/40, Joker, Noroc, Super Noroc, Noroc Plus)", name)
+}
+
 // Extraction represents a single lottery draw result
 type Extraction struct {
-	Game    Game
-	Date    string
-	Numbers []int
-	Bonus   []int // Joker bonus number, Noroc/Super Noroc number
+	Game    Game   `json:"game"`
+	Date    string `json:"date"`
+	Draw    int    `json:"draw,omitempty"` // 0 for the main draw, 1, 2... for additional draws published alongside it
+	Numbers []int  `json:"numbers"`
+	Bonus   []int  `json:"bonus,omitempty"` // Joker bonus number
+}
+
+// Label returns the game name, numbering additional draws (e.g. "Loto 6/49 (draw 2)")
+func (e Extraction) Label() string {
+	if e.Draw == 0 {
+		return string(e.Game)
+	}
+	return fmt.Sprintf("%s (draw %d)", e.Game, e.Draw+1)
 }
 
-// Ticket represents a purchased lottery ticket
+// Ticket represents a purchased lottery ticket.
+// The string fields hold the values as shown on the site; Parse fills in their
+// typed counterparts (PriceAmount, PrizeAmount, DrawTime, PlayedTime).
 type Ticket struct {
-	OrderID   string
-	TicketID  string
-	Game      Game
-	Price     string // e.g. "24,50 RON"
-	DrawDate  string // e.g. "15.02.2026"
-	Status    TicketStatus
-	PlayedAt  string // e.g. "Jo 12 feb 2026, Ora 18:58"
-	DetailURL string
-	Prize     string // e.g. "30,00 RON" — only populated from detail page for won tickets
+	OrderID   string       `json:"order_id"`
+	TicketID  string       `json:"ticket_id"`
+	Game      Game         `json:"game"`
+	Price     string       `json:"price"`     // e.g. "24,50 RON"
+	DrawDate  string       `json:"draw_date"` // e.g. "15.02.2026"
+	Status    TicketStatus `json:"status"`
+	PlayedAt  string       `json:"played_at"` // e.g. "Jo 12 feb 2026, Ora 18:58"
+	DetailURL string       `json:"detail_url"`
+	Prize     string       `json:"prize"`           // e.g. "30,00 RON" — only populated from detail page for won tickets
+	Lines     []PlayedLine `json:"lines,omitempty"` // only populated from detail page
+	Noroc     []NorocEntry `json:"noroc,omitempty"` // only populated from detail page
+
+	PriceAmount Money     `json:"price_amount,omitzero"`
+	PrizeAmount Money     `json:"prize_amount,omitzero"`
+	DrawTime    time.Time `json:"draw_time,omitzero"`   // draw day at midnight, local time
+	PlayedTime  time.Time `json:"played_time,omitzero"` // local time
+}
+
+// Parse fills the typed fields from the raw strings. Values that can't be
+// parsed are left zero.
+func (t *Ticket) Parse() {
+	t.PriceAmount, _ = ParseMoney(t.Price)
+	t.PrizeAmount = Money{}
+	if t.Prize != "" {
+		t.PrizeAmount, _ = ParseMoney(t.Prize)
+	}
+	t.DrawTime, _ = ParseDrawDate(t.DrawDate)
+	t.PlayedTime, _ = ParseRomanianTime(t.PlayedAt)
+	for i := range t.Lines {
+		t.Lines[i].Parse()
+	}
+}
+
+// PlayedLine is a single variant (grid line) played on a ticket
+type PlayedLine struct {
+	Numbers     []int  `json:"numbers"`
+	Joker       int    `json:"joker,omitempty"`    // Joker number, only for Joker tickets
+	Category    string `json:"category,omitempty"` // prize category won by this line, e.g. "III"
+	Prize       string `json:"prize,omitempty"`    // prize won by this line, e.g. "30,00 RON"
+	PrizeAmount Money  `json:"prize_amount,omitzero"`
+}
+
+// Parse fills PrizeAmount from Prize
+func (l *PlayedLine) Parse() {
+	l.PrizeAmount = Money{}
+	if l.Prize != "" {
+		l.PrizeAmount, _ = ParseMoney(l.Prize)
+	}
+}
+
+// NorocEntry is a Noroc, Super Noroc or Noroc Plus participation on a ticket
+type NorocEntry struct {
+	Game   Game   `json:"game"`
+	Number string `json:"number"` // kept as a string, leading zeros are significant
+}
+
+// TicketDetails is the information parsed from a ticket detail page
+type TicketDetails struct {
+	Lines []PlayedLine
+	Noroc []NorocEntry
+	Prize string // total prize, e.g. "30,00 RON"
+}
+
+// Apply copies the parsed details onto a ticket, keeping existing values for anything not found
+func (d *TicketDetails) Apply(t *Ticket) {
+	if d.Prize != "" {
+		t.Prize = d.Prize
+	}
+	if len(d.Lines) > 0 {
+		t.Lines = d.Lines
+	}
+	if len(d.Noroc) > 0 {
+		t.Noroc = d.Noroc
+	}
+	t.Parse()
 }
 
 // TicketStatus represents the status of a ticket
@@ -56,6 +166,50 @@ func (s TicketStatus) String() string {
 	}
 }
 
+// Key returns the stable machine-readable identifier for a ticket status.
+// These values are part of the JSON output schema and must not change.
+func (s TicketStatus) Key() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusWon:
+		return "won"
+	case StatusLost:
+		return "lost"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseTicketStatus maps a status key (as returned by Key) back to a TicketStatus
+func ParseTicketStatus(key string) TicketStatus {
+	switch key {
+	case "pending":
+		return StatusPending
+	case "won":
+		return StatusWon
+	case "lost":
+		return StatusLost
+	default:
+		return StatusUnknown
+	}
+}
+
+// MarshalJSON encodes the status as its stable string key
+func (s TicketStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Key())
+}
+
+// UnmarshalJSON decodes a status from its string key
+func (s *TicketStatus) UnmarshalJSON(data []byte) error {
+	var key string
+	if err := json.Unmarshal(data, &key); err != nil {
+		return err
+	}
+	*s = ParseTicketStatus(key)
+	return nil
+}
+
 // GameFromImagePath maps an image filename to a Game type
 func GameFromImagePath(path string) Game {
 	switch {
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CurrencyRON is the currency of every ticket and prize on bilete.loto.ro
+const CurrencyRON = "RON"
+
+// Money is an exact amount in the smallest unit of its currency (bani for RON)
+type Money struct {
+	Amount   int64  `json:"amount"`   // e.g. 2450 for 24,50 RON
+	Currency string `json:"currency"` // ISO 4217 code, e.g. "RON"
+}
+
+// RON returns an amount of lei and bani
+func RON(lei, bani int64) Money {
+	return Money{Amount: lei*100 + bani, Currency: CurrencyRON}
+}
+
+// ParseMoney parses an amount as shown by loto.ro: "24,50 RON", "1.234,56 lei",
+// "30 RON" or "24.50". Dots group thousands when there is a decimal comma or
+// more than one dot; a single dot is a decimal point, so "24.5" is 24,50, and
+// "1.234" is rejected as ambiguous. Amounts without a currency are in RON.
+func ParseMoney(s string) (Money, error) {
+	raw := strings.TrimSpace(s)
+	text := strings.ToLower(raw)
+
+	m := Money{Currency: CurrencyRON}
+	for _, c := range []struct{ suffix, code string }{
+		{"ron", "RON"}, {"lei", "RON"}, {"leu", "RON"}, {"euro", "EUR"}, {"eur", "EUR"}, {"€", "EUR"},
+	} {
+		if strings.HasSuffix(text, c.suffix) {
+			text = strings.TrimSpace(strings.TrimSuffix(text, c.suffix))
+			m.Currency = c.code
+			break
+		}
+	}
+	text = strings.ReplaceAll(text, " ", "")
+
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+
+	units, fraction := text, ""
+	if i := strings.LastIndex(text, ","); i >= 0 {
+		units, fraction = text[:i], text[i+1:]
+	} else if strings.Count(text, ".") == 1 {
+		i := strings.Index(text, ".")
+		units, fraction = text[:i], text[i+1:]
+		if len(fraction) > 2 {
+			return Money{}, fmt.Errorf("ambiguous amount %q (write thousands as 1234 or 1.234,00)", raw)
+		}
+	}
+	units = strings.ReplaceAll(units, ".", "")
+
+	if units == "" || len(fraction) > 2 {
+		return Money{}, fmt.Errorf("invalid amount %q", raw)
+	}
+	lei, err := strconv.ParseInt(units, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q", raw)
+	}
+	var bani int64
+	if fraction != "" {
+		if bani, err = strconv.ParseInt(fraction, 10, 64); err != nil {
+			return Money{}, fmt.Errorf("invalid amount %q", raw)
+		}
+		if len(fraction) == 1 {
+			bani *= 10
+		}
+	}
+
+	m.Amount = lei*100 + bani
+	if negative {
+		m.Amount = -m.Amount
+	}
+	return m, nil
+}
+
+// Add returns the sum of two amounts. An empty currency takes the other's.
+func (m Money) Add(o Money) Money {
+	if m.Currency == "" {
+		m.Currency = o.Currency
+	}
+	m.Amount += o.Amount
+	return m
+}
+
+// Sub returns m minus o
+func (m Money) Sub(o Money) Money {
+	return m.Add(Money{Amount: -o.Amount, Currency: o.Currency})
+}
+
+// IsZero reports whether the amount is zero
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Float returns the amount in whole currency units, for display and ratios
+func (m Money) Float() float64 {
+	return float64(m.Amount) / 100
+}
+
+// Decimal formats the amount with a decimal point and no grouping or currency,
+// e.g. "-1234.56", as spreadsheets and accounting tools expect
+func (m Money) Decimal() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// String formats the amount the way loto.ro does, e.g. "1.234,56 RON"
+func (m Money) String() string {
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	units := strconv.FormatInt(amount/100, 10)
+	var grouped strings.Builder
+	for i, ch := range units {
+		if i > 0 && (len(units)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(ch)
+	}
+
+	currency := m.Currency
+	if currency == "" {
+		currency = CurrencyRON
+	}
+	return fmt.Sprintf("%s%s,%02d %s", sign, grouped.String(), amount%100, currency)
+}
@@ -0,0 +1,66 @@
+package models
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in       string
+		amount   int64
+		currency string
+	}{
+		{"24,50 RON", 2450, "RON"},
+		{"1.234,56 lei", 123456, "RON"},
+		{"21.345.678,90", 2134567890, "RON"},
+		{"30 RON", 3000, "RON"},
+		{"30", 3000, "RON"},
+		{"24.50", 2450, "RON"},
+		{"24.5", 2450, "RON"},
+		{"24,5", 2450, "RON"},
+		{"1.234.567", 123456700, "RON"},
+		{"  -7,50 RON ", -750, "RON"},
+		{"+1 leu", 100, "RON"},
+		{"12,00 €", 1200, "EUR"},
+		{"5 euro", 500, "EUR"},
+	}
+	for _, tt := range tests {
+		m, err := ParseMoney(tt.in)
+		if err != nil {
+			t.Errorf("ParseMoney(%q): %v", tt.in, err)
+			continue
+		}
+		if m.Amount != tt.amount || m.Currency != tt.currency {
+			t.Errorf("ParseMoney(%q) = %d %s, want %d %s", tt.in, m.Amount, m.Currency, tt.amount, tt.currency)
+		}
+	}
+
+	// A single dot with three digits could group thousands or be a decimal point
+	for _, in := range []string{"", "RON", "abc", "1.234", "24.500", "1,234", "12,3,4", "1.2.3,4a"} {
+		if m, err := ParseMoney(in); err == nil {
+			t.Errorf("ParseMoney(%q) = %+v, want an error", in, m)
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		m       Money
+		str     string
+		decimal string
+	}{
+		{RON(24, 50), "24,50 RON", "24.50"},
+		{RON(1234567, 8), "1.234.567,08 RON", "1234567.08"},
+		{Money{Amount: -123456}, "-1.234,56 RON", "-1234.56"},
+		{Money{}, "0,00 RON", "0.00"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.str {
+			t.Errorf("String() = %q, want %q", got, tt.str)
+		}
+		if got := tt.m.Decimal(); got != tt.decimal {
+			t.Errorf("Decimal() = %q, want %q", got, tt.decimal)
+		}
+		if back, err := ParseMoney(tt.str); err != nil || back.Amount != tt.m.Amount {
+			t.Errorf("ParseMoney(%q) = %d, %v, want %d", tt.str, back.Amount, err, tt.m.Amount)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+// PrizeReport is the prize breakdown of a draw and the jackpot of the next one
+type PrizeReport struct {
+	Game       Game             `json:"game"`
+	Date       string           `json:"date,omitempty"` // draw the categories belong to, e.g. "15.02.2026"
+	Categories []CategoryPayout `json:"categories"`
+	Jackpot    string           `json:"jackpot,omitempty"`   // category I estimate for the next draw, e.g. "5.123.456,78 lei"
+	NextDraw   string           `json:"next_draw,omitempty"` // date of the next draw, when published
+}
+
+// CategoryPayout is the number of winners and the prize of one category in a draw
+type CategoryPayout struct {
+	Category string `json:"category"`           // roman numeral, e.g. "III"
+	Winners  int    `json:"winners"`            // winning variants, 0 when nobody won
+	Prize    string `json:"prize,omitempty"`    // prize per winning variant as shown, e.g. "1.234,56 lei"
+	Rollover string `json:"rollover,omitempty"` // amount carried over to the next draw ("report")
+}
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TicketSort is the order a TicketQuery returns tickets in
+type TicketSort string
+
+const (
+	SortSite  TicketSort = ""      // site order, newest first
+	SortDate  TicketSort = "date"  // draw date, then time played
+	SortPrice TicketSort = "price" // ticket price
+	SortPrize TicketSort = "prize" // prize won
+)
+
+// TicketSorts lists the orders accepted by ParseTicketSort
+var TicketSorts = []TicketSort{SortDate, SortPrice, SortPrize}
+
+// ParseTicketSort maps a user-supplied sort key to a TicketSort
+func ParseTicketSort(key string) (TicketSort, error) {
+	s := TicketSort(strings.ToLower(strings.TrimSpace(key)))
+	if s == SortSite || slices.Contains(TicketSorts, s) {
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown sort %q (expected date, price or prize)", key)
+}
+
+// TicketQuery filters, sorts and limits a ticket history. Zero values mean "no filter".
+type TicketQuery struct {
+	Game      Game
+	Status    TicketStatus // StatusUnknown matches every status
+	From      time.Time    // draw date, inclusive
+	To        time.Time    // draw date, inclusive
+	MinPrize  Money        // tickets that won at least this much
+	Search    string       // case-insensitive part of the ticket or order ID
+	Sort      TicketSort
+	Ascending bool // sorts lowest first; by default the newest, dearest or biggest prize comes first
+	Limit     int  // 0 returns every matching ticket
+}
+
+// Match reports whether a ticket passes the query's filters
+func (q TicketQuery) Match(t Ticket) bool {
+	if q.Game != "" && t.Game != q.Game {
+		return false
+	}
+	if q.Status != StatusUnknown && t.Status != q.Status {
+		return false
+	}
+	if !q.From.IsZero() && (t.DrawTime.IsZero() || t.DrawTime.Before(q.From)) {
+		return false
+	}
+	if !q.To.IsZero() && (t.DrawTime.IsZero() || t.DrawTime.After(q.To)) {
+		return false
+	}
+	if !q.MinPrize.IsZero() && (t.PrizeAmount.IsZero() || t.PrizeAmount.Amount < q.MinPrize.Amount) {
+		return false
+	}
+	if q.Search != "" {
+		search := strings.ToLower(strings.TrimSpace(q.Search))
+		if !strings.Contains(strings.ToLower(t.TicketID), search) && !strings.Contains(strings.ToLower(t.OrderID), search) {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns the matching tickets in the query's order, leaving tickets unchanged.
+// Tickets that sort equally keep their site order.
+func (q TicketQuery) Apply(tickets []Ticket) []Ticket {
+	matched := make([]Ticket, 0, len(tickets))
+	for _, t := range tickets {
+		if q.Match(t) {
+			matched = append(matched, t)
+		}
+	}
+
+	if q.Sort != SortSite {
+		slices.SortStableFunc(matched, func(a, b Ticket) int {
+			c := q.compare(a, b)
+			if !q.Ascending {
+				c = -c
+			}
+			return c
+		})
+	} else if q.Ascending {
+		slices.Reverse(matched)
+	}
+
+	if q.Limit > 0 && len(matched) > q.Limit {
+		matched = matched[:q.Limit]
+	}
+	return matched
+}
+
+// compare orders two tickets by the query's sort key, lowest first
+func (q TicketQuery) compare(a, b Ticket) int {
+	switch q.Sort {
+	case SortDate:
+		if c := a.DrawTime.Compare(b.DrawTime); c != 0 {
+			return c
+		}
+		return a.PlayedTime.Compare(b.PlayedTime)
+	case SortPrice:
+		return cmp.Compare(a.PriceAmount.Amount, b.PriceAmount.Amount)
+	case SortPrize:
+		return cmp.Compare(a.PrizeAmount.Amount, b.PrizeAmount.Amount)
+	default:
+		return 0
+	}
+}
+
+// String describes the query's filters and order, e.g. "Joker, won, by prize",
+// or returns an empty string for the zero query
+func (q TicketQuery) String() string {
+	var parts []string
+	if q.Game != "" {
+		parts = append(parts, string(q.Game))
+	}
+	if q.Status != StatusUnknown {
+		parts = append(parts, q.Status.Key())
+	}
+	switch {
+	case !q.From.IsZero() && !q.To.IsZero():
+		parts = append(parts, q.From.Format("02.01.2006")+"–"+q.To.Format("02.01.2006"))
+	case !q.From.IsZero():
+		parts = append(parts, "from "+q.From.Format("02.01.2006"))
+	case !q.To.IsZero():
+		parts = append(parts, "until "+q.To.Format("02.01.2006"))
+	}
+	if !q.MinPrize.IsZero() {
+		parts = append(parts, "prize ≥ "+q.MinPrize.String())
+	}
+	if q.Search != "" {
+		parts = append(parts, fmt.Sprintf("%q", q.Search))
+	}
+	if q.Sort != SortSite {
+		order := "by " + string(q.Sort)
+		if q.Ascending {
+			order += " ascending"
+		}
+		parts = append(parts, order)
+	} else if q.Ascending {
+		parts = append(parts, "oldest first")
+	}
+	if q.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("first %d", q.Limit))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// queryTickets are in site order, newest first
+func queryTickets() []Ticket {
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+	return []Ticket{
+		{TicketID: "A105", OrderID: "O9", Game: GameJoker, Status: StatusPending, DrawTime: day(12), PriceAmount: RON(10, 0)},
+		{TicketID: "A104", OrderID: "O8", Game: GameLoto649, Status: StatusWon, DrawTime: day(8), PlayedTime: day(7).Add(20 * time.Hour), PriceAmount: RON(21, 50), PrizeAmount: RON(30, 0)},
+		{TicketID: "A103", OrderID: "O8", Game: GameLoto649, Status: StatusWon, DrawTime: day(8), PlayedTime: day(7).Add(19 * time.Hour), PriceAmount: RON(7, 50), PrizeAmount: RON(1250, 40)},
+		{TicketID: "B102", OrderID: "O7", Game: GameJoker, Status: StatusLost, DrawTime: day(5), PriceAmount: RON(10, 0)},
+		{TicketID: "B101", OrderID: "O6", Game: GameLoto540, Status: StatusWon, DrawTime: day(1), PriceAmount: RON(5, 0), PrizeAmount: RON(12, 0)},
+		{TicketID: "B100", OrderID: "O5", Game: GameLoto540, Status: StatusUnknown, PriceAmount: RON(5, 0)}, // no draw date
+	}
+}
+
+func ticketIDs(tickets []Ticket) string {
+	ids := make([]string, len(tickets))
+	for i, t := range tickets {
+		ids[i] = t.TicketID
+	}
+	return fmt.Sprint(ids)
+}
+
+func TestTicketQueryApply(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name  string
+		query TicketQuery
+		want  string
+	}{
+		{"everything", TicketQuery{}, "[A105 A104 A103 B102 B101 B100]"},
+		{"game", TicketQuery{Game: GameJoker}, "[A105 B102]"},
+		{"status", TicketQuery{Status: StatusWon}, "[A104 A103 B101]"},
+		{"from inclusive", TicketQuery{From: day(8)}, "[A105 A104 A103]"},
+		{"to inclusive", TicketQuery{To: day(5)}, "[B102 B101]"},
+		{"from and to", TicketQuery{From: day(5), To: day(8)}, "[A104 A103 B102]"},
+		{"min prize", TicketQuery{MinPrize: RON(30, 0)}, "[A104 A103]"},
+		{"min prize in bani", TicketQuery{MinPrize: RON(30, 1)}, "[A103]"},
+		{"search ticket ID", TicketQuery{Search: "b10"}, "[B102 B101 B100]"},
+		{"search order ID", TicketQuery{Search: " o8 "}, "[A104 A103]"},
+		{"search no match", TicketQuery{Search: "zzz"}, "[]"},
+
+		{"site order ascending", TicketQuery{Ascending: true}, "[B100 B101 B102 A103 A104 A105]"},
+		// A104 and A103 share a draw date, A104 was played later
+		{"date", TicketQuery{Sort: SortDate}, "[A105 A104 A103 B102 B101 B100]"},
+		{"date ascending", TicketQuery{Sort: SortDate, Ascending: true}, "[B100 B101 B102 A103 A104 A105]"},
+		// Equal prices keep their site order
+		{"price", TicketQuery{Sort: SortPrice}, "[A104 A105 B102 A103 B101 B100]"},
+		{"price ascending", TicketQuery{Sort: SortPrice, Ascending: true}, "[B101 B100 A103 A105 B102 A104]"},
+		{"prize", TicketQuery{Sort: SortPrize, Status: StatusWon}, "[A103 A104 B101]"},
+		{"prize ascending", TicketQuery{Sort: SortPrize, Status: StatusWon, Ascending: true}, "[B101 A104 A103]"},
+
+		{"limit", TicketQuery{Limit: 2}, "[A105 A104]"},
+		{"limit after sorting", TicketQuery{Sort: SortPrize, Limit: 1}, "[A103]"},
+		{"limit above matches", TicketQuery{Game: GameJoker, Limit: 5}, "[A105 B102]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tickets := queryTickets()
+			if got := ticketIDs(tt.query.Apply(tickets)); got != tt.want {
+				t.Errorf("Apply = %s, want %s", got, tt.want)
+			}
+			if got := ticketIDs(tickets); got != "[A105 A104 A103 B102 B101 B100]" {
+				t.Errorf("Apply reordered its input: %s", got)
+			}
+		})
+	}
+}
+
+func TestTicketQueryMatch(t *testing.T) {
+	day := time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC)
+	q := TicketQuery{From: day, To: day, MinPrize: RON(1, 0)}
+
+	if !q.Match(Ticket{DrawTime: day, PrizeAmount: RON(1, 0)}) {
+		t.Error("Match: a ticket on both bounds with the minimum prize should match")
+	}
+	for name, tk := range map[string]Ticket{
+		"day before": {DrawTime: day.AddDate(0, 0, -1), PrizeAmount: RON(5, 0)},
+		"day after":  {DrawTime: day.AddDate(0, 0, 1), PrizeAmount: RON(5, 0)},
+		"no date":    {PrizeAmount: RON(5, 0)},
+		"no prize":   {DrawTime: day},
+		"low prize":  {DrawTime: day, PrizeAmount: RON(0, 99)},
+	} {
+		if q.Match(tk) {
+			t.Errorf("Match(%s) = true, want false", name)
+		}
+	}
+}
+
+func TestParseTicketSort(t *testing.T) {
+	tests := []struct {
+		key  string
+		want TicketSort
+		err  bool
+	}{
+		{"", SortSite, false},
+		{"date", SortDate, false},
+		{" Price ", SortPrice, false},
+		{"PRIZE", SortPrize, false},
+		{"amount", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseTicketSort(tt.key)
+		if got != tt.want || (err != nil) != tt.err {
+			t.Errorf("ParseTicketSort(%q) = %q, %v; want %q, error %v", tt.key, got, err, tt.want, tt.err)
+		}
+	}
+}
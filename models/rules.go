@@ -0,0 +1,165 @@
+package models
+
+import "fmt"
+
+// GameRules describes how a game is played and which results win a prize
+type GameRules struct {
+	Game     Game
+	Pick     int // numbers in a simple variant
+	Max      int // highest playable number
+	Drawn    int // numbers drawn per draw
+	JokerMax int // highest Joker number, 0 if the game has no Joker
+	// Categories lists the winning combinations, best first
+	Categories []PrizeCategory
+}
+
+// PrizeCategory is a winning combination of hits and its category numeral
+type PrizeCategory struct {
+	Name  string // roman numeral, e.g. "III"
+	Hits  int    // matched numbers (or matched leading/trailing digits for Noroc games)
+	Joker bool   // whether the Joker number must match too
+	First int    // if set, the Hits must be among the first First numbers drawn
+}
+
+// DigitRules describes a Noroc-style game where a single number is drawn digit by digit
+type DigitRules struct {
+	Game   Game
+	Digits int
+	// Categories lists the winning combinations, best first. Hits is the number of
+	// digits matched in order from the left or from the right.
+	Categories []PrizeCategory
+}
+
+// lottoRules holds the rules of the number-pick games
+var lottoRules = map[Game]GameRules{
+	GameLoto649: {
+		Game: GameLoto649, Pick: 6, Max: 49, Drawn: 6,
+		Categories: []PrizeCategory{{Name: "I", Hits: 6}, {Name: "II", Hits: 5}, {Name: "III", Hits: 4}, {Name: "IV", Hits: 3}},
+	},
+	// Loto 5/40 variants are 6 numbers. Category I needs the first 5 numbers drawn;
+	// the others count hits among all 6 numbers drawn.
+	GameLoto540: {
+		Game: GameLoto540, Pick: 6, Max: 40, Drawn: 6,
+		Categories: []PrizeCategory{{Name: "I", Hits: 5, First: 5}, {Name: "II", Hits: 5}, {Name: "III", Hits: 4}, {Name: "IV", Hits: 3}},
+	},
+	GameJoker: {
+		Game: GameJoker, Pick: 5, Max: 45, Drawn: 5, JokerMax: 20,
+		Categories: []PrizeCategory{
+			{Name: "I", Hits: 5, Joker: true}, {Name: "II", Hits: 5}, {Name: "III", Hits: 4, Joker: true}, {Name: "IV", Hits: 4},
+			{Name: "V", Hits: 3, Joker: true}, {Name: "VI", Hits: 3}, {Name: "VII", Hits: 2, Joker: true}, {Name: "VIII", Hits: 1, Joker: true},
+		},
+	},
+}
+
+// digitRules holds the rules of the Noroc games
+var digitRules = map[Game]DigitRules{
+	GameNoroc: {
+		Game: GameNoroc, Digits: 7,
+		Categories: []PrizeCategory{{Name: "I", Hits: 7}, {Name: "II", Hits: 6}, {Name: "III", Hits: 5}, {Name: "IV", Hits: 4}, {Name: "V", Hits: 3}, {Name: "VI", Hits: 2}},
+	},
+	GameSuperNoroc: {
+		Game: GameSuperNoroc, Digits: 6,
+		Categories: []PrizeCategory{{Name: "I", Hits: 6}, {Name: "II", Hits: 5}, {Name: "III", Hits: 4}, {Name: "IV", Hits: 3}},
+	},
+	GameNorocPlus: {
+		Game: GameNorocPlus, Digits: 6,
+		Categories: []PrizeCategory{{Name: "I", Hits: 6}, {Name: "II", Hits: 5}, {Name: "III", Hits: 4}, {Name: "IV", Hits: 3}, {Name: "V", Hits: 2}, {Name: "VI", Hits: 1}},
+	},
+}
+
+// RulesFor returns the rules of a number-pick game (Loto 6/49, Loto 5/40, Joker)
+func RulesFor(g Game) (GameRules, bool) {
+	r, ok := lottoRules[g]
+	return r, ok
+}
+
+// category returns the best category won by a variant with hits numbers drawn,
+// firstHits of them among the first numbers drawn, and whether its Joker matched
+func (r GameRules) category(hits, firstHits int, joker bool) (PrizeCategory, bool) {
+	for _, cat := range r.Categories {
+		// Joker categories are split on whether the Joker matched
+		if r.JokerMax > 0 && cat.Joker != joker {
+			continue
+		}
+		if cat.First > 0 && firstHits >= cat.Hits || cat.First == 0 && hits == cat.Hits {
+			return cat, true
+		}
+	}
+	return PrizeCategory{}, false
+}
+
+// firstDrawn returns how many of the numbers drawn first are told apart by a category
+func (r GameRules) firstDrawn() int {
+	first := r.Drawn
+	for _, cat := range r.Categories {
+		if cat.First > 0 {
+			first = min(first, cat.First)
+		}
+	}
+	return first
+}
+
+// DigitRulesFor returns the rules of a Noroc game
+func DigitRulesFor(g Game) (DigitRules, bool) {
+	r, ok := digitRules[g]
+	return r, ok
+}
+
+// ValidateLine checks that a played line is allowed by the game rules.
+// Lines with more numbers than Pick are combined variants and are allowed.
+func (r GameRules) ValidateLine(line PlayedLine) error {
+	if len(line.Numbers) < r.Pick {
+		return fmt.Errorf("%s needs at least %d numbers, got %d", r.Game, r.Pick, len(line.Numbers))
+	}
+	seen := make(map[int]bool)
+	for _, n := range line.Numbers {
+		if n < 1 || n > r.Max {
+			return fmt.Errorf("%s numbers must be between 1 and %d, got %d", r.Game, r.Max, n)
+		}
+		if seen[n] {
+			return fmt.Errorf("number %d is repeated", n)
+		}
+		seen[n] = true
+	}
+	if r.JokerMax > 0 && (line.Joker < 1 || line.Joker > r.JokerMax) {
+		return fmt.Errorf("%s needs a Joker number between 1 and %d", r.Game, r.JokerMax)
+	}
+	if r.JokerMax == 0 && line.Joker != 0 {
+		return fmt.Errorf("%s has no Joker number", r.Game)
+	}
+	return nil
+}
+
+// Validate checks that a played Noroc number has the right number of digits
+func (r DigitRules) Validate(number string) error {
+	if len(number) != r.Digits {
+		return fmt.Errorf("%s numbers have %d digits, got %q", r.Game, r.Digits, number)
+	}
+	for _, ch := range number {
+		if ch < '0' || ch > '9' {
+			return fmt.Errorf("%s number %q must contain only digits", r.Game, number)
+		}
+	}
+	return nil
+}
+
+// IsDigitGame reports whether g is a Noroc game, whose draw is a single number
+// stored digit by digit
+func (g Game) IsDigitGame() bool {
+	_, ok := digitRules[g]
+	return ok
+}
+
+// NorocGameFor returns the Noroc game played alongside a main game
+func NorocGameFor(g Game) Game {
+	switch g {
+	case GameLoto649:
+		return GameNoroc
+	case GameLoto540:
+		return GameSuperNoroc
+	case GameJoker:
+		return GameNorocPlus
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Stats is an aggregated summary of a ticket history
+type Stats struct {
+	TotalTickets   int         `json:"total_tickets"`
+	TotalSpent     float64     `json:"total_spent"`
+	TotalWon       float64     `json:"total_won"`
+	NetResult      float64     `json:"net_result"`
+	AvgTicketPrice float64     `json:"avg_ticket_price"`
+	FirstDrawDate  string      `json:"first_draw_date"`
+	LastDrawDate   string      `json:"last_draw_date"`
+	Won            int         `json:"won"`
+	Lost           int         `json:"lost"`
+	Pending        int         `json:"pending"`
+	WinRate        float64     `json:"win_rate"` // percentage of decided (won + lost) tickets
+	ByGame         []GameStats `json:"by_game"`
+}
+
+// GameStats is the per-game part of Stats
+type GameStats struct {
+	Game      Game    `json:"game"`
+	Tickets   int     `json:"tickets"`
+	Spent     float64 `json:"spent"`
+	Won       int     `json:"won"`
+	WonAmount float64 `json:"won_amount"`
+}
+
+// gameTotals accumulates exact per-game amounts before they are converted for Stats
+type gameTotals struct {
+	stats      GameStats
+	spent, won Money
+}
+
+// ComputeStats aggregates tickets into a Stats summary.
+// Tickets are expected in site order (newest first). Amounts are summed exactly
+// in bani and converted to RON at the end.
+func ComputeStats(tickets []Ticket) Stats {
+	var s Stats
+	if len(tickets) == 0 {
+		return s
+	}
+
+	byGame := make(map[Game]*gameTotals)
+
+	var spent, won Money
+	for _, t := range tickets {
+		spent = spent.Add(t.PriceAmount)
+
+		gt, ok := byGame[t.Game]
+		if !ok {
+			gt = &gameTotals{stats: GameStats{Game: t.Game}}
+			byGame[t.Game] = gt
+		}
+		gt.stats.Tickets++
+		gt.spent = gt.spent.Add(t.PriceAmount)
+
+		switch t.Status {
+		case StatusWon:
+			s.Won++
+			gt.stats.Won++
+			won = won.Add(t.PrizeAmount)
+			gt.won = gt.won.Add(t.PrizeAmount)
+		case StatusLost:
+			s.Lost++
+		case StatusPending:
+			s.Pending++
+		}
+	}
+
+	s.TotalTickets = len(tickets)
+	s.TotalSpent = spent.Float()
+	s.TotalWon = won.Float()
+
+	// Site order is newest first; parsed draw dates take precedence so any order works
+	first, last := tickets[len(tickets)-1], tickets[0]
+	for _, t := range tickets {
+		if t.DrawTime.IsZero() {
+			continue
+		}
+		if first.DrawTime.IsZero() || t.DrawTime.Before(first.DrawTime) {
+			first = t
+		}
+		if last.DrawTime.IsZero() || t.DrawTime.After(last.DrawTime) {
+			last = t
+		}
+	}
+	s.FirstDrawDate = first.DrawDate
+	s.LastDrawDate = last.DrawDate
+	s.AvgTicketPrice = s.TotalSpent / float64(s.TotalTickets)
+	s.NetResult = won.Sub(spent).Float()
+
+	if decided := s.Won + s.Lost; decided > 0 {
+		s.WinRate = float64(s.Won) / float64(decided) * 100
+	}
+
+	// Known games in display order, then any others by name
+	games := slices.Collect(maps.Keys(byGame))
+	slices.SortFunc(games, func(a, b Game) int {
+		ia, ib := slices.Index(AllGames, a), slices.Index(AllGames, b)
+		if ia < 0 && ib < 0 {
+			return strings.Compare(string(a), string(b))
+		}
+		if ia < 0 || ib < 0 {
+			return cmp.Compare(ib, ia) // the known game first
+		}
+		return cmp.Compare(ia, ib)
+	})
+	for _, g := range games {
+		gt := byGame[g]
+		gt.stats.Spent = gt.spent.Float()
+		gt.stats.WonAmount = gt.won.Float()
+		s.ByGame = append(s.ByGame, gt.stats)
+	}
+
+	return s
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// globalOptions holds options accepted by every command
+type globalOptions struct {
+	format  outputFormat
+	offline bool   // read tickets from the local archive instead of bilete.loto.ro
+	profile string // account profile, empty uses LOTO_PROFILE or the default profile
+}
+
+// opts is the parsed set of global options for this invocation
+var opts = globalOptions{format: formatText}
+
+// commandValueFlags lists, per command, the flags that take a value, so that value is
+// never read as a global option (as in "tickets --search -p"). export's --format and
+// -f are its own. Keep in sync with the flag sets of the commands.
+var commandValueFlags = map[string][]string{
+	"results":       {"game", "date", "from", "to", "page", "per-page"},
+	"tickets":       {"game", "status", "from", "to", "min-prize", "search", "sort", "limit"},
+	"stats":         {"period"},
+	"check-numbers": {"game", "joker", "date", "file"},
+	"jackpot":       {"game"},
+	"analyze":       {"game", "from", "to", "last", "top"},
+	"export":        {"format", "f", "out", "o", "game", "from", "to"},
+	"login":         {"backend"},
+	"doctor":        {"dump"},
+}
+
+// parseGlobalFlags extracts global options from anywhere in args and returns the remaining
+// args. Values of the command's own flags are left alone, and so is everything after "--".
+func parseGlobalFlags(args []string) (globalOptions, []string, error) {
+	o := globalOptions{format: formatText}
+	var rest []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+
+		// A flag of the command itself, with its value in this arg or the next one
+		if len(rest) > 0 && strings.HasPrefix(arg, "-") {
+			name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+			if slices.Contains(commandValueFlags[rest[0]], name) {
+				rest = append(rest, arg)
+				if !hasValue && i+1 < len(args) {
+					i++
+					rest = append(rest, args[i])
+				}
+				continue
+			}
+		}
+
+		var value string
+		switch {
+		case arg == "--format" || arg == "-f":
+			if i+1 >= len(args) {
+				return o, nil, fmt.Errorf("%s requires a value (text, json or ndjson)", arg)
+			}
+			i++
+			value = args[i]
+		case strings.HasPrefix(arg, "--format="):
+			value = strings.TrimPrefix(arg, "--format=")
+		case arg == "--offline":
+			o.offline = true
+			continue
+		case arg == "--profile" || arg == "-p":
+			if i+1 >= len(args) {
+				return o, nil, fmt.Errorf("%s requires a profile name", arg)
+			}
+			i++
+			o.profile = args[i]
+			continue
+		case strings.HasPrefix(arg, "--profile="):
+			o.profile = strings.TrimPrefix(arg, "--profile=")
+			continue
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+
+		switch f := outputFormat(strings.ToLower(value)); f {
+		case formatText, formatJSON, formatNDJSON:
+			o.format = f
+		default:
+			return o, nil, fmt.Errorf("unknown format %q (expected text, json or ndjson)", value)
+		}
+	}
+
+	return o, rest, nil
+}
+
+// parseInterspersed parses command flags that may appear before, between or after
+// positional args and returns the positional args in order
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseGlobalFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		format  outputFormat
+		offline bool
+		profile string
+		rest    string
+	}{
+		{"none", []string{"tickets"}, formatText, false, "", "[tickets]"},
+		{"before the command", []string{"-f", "json", "--offline", "-p", "alice", "stats"}, formatJSON, true, "alice", "[stats]"},
+		{"after the command", []string{"stats", "--format=ndjson", "--profile=bob", "--period", "week"}, formatNDJSON, false, "bob", "[stats --period week]"},
+		{"between positional args", []string{"check-numbers", "1", "-f", "json", "2", "3"}, formatJSON, false, "", "[check-numbers 1 2 3]"},
+
+		// Values of the command's own flags are not global options
+		{"search for -p", []string{"tickets", "--search", "-p", "-p", "alice"}, formatText, false, "alice", "[tickets --search -p]"},
+		{"search for -f", []string{"tickets", "-search", "-f", "--offline"}, formatText, true, "", "[tickets -search -f]"},
+		{"value after =", []string{"tickets", "--search=-p", "-p", "alice"}, formatText, false, "alice", "[tickets --search=-p]"},
+		{"dump to --offline", []string{"doctor", "--dump", "--offline"}, formatText, false, "", "[doctor --dump --offline]"},
+
+		// export has its own --format
+		{"export -f", []string{"export", "-f", "xlsx", "-o", "t.xlsx"}, formatText, false, "", "[export -f xlsx -o t.xlsx]"},
+		{"export --format=", []string{"export", "--format=ofx", "--offline"}, formatText, true, "", "[export --format=ofx]"},
+		{"export with a global format first", []string{"-f", "json", "export", "--format", "csv"}, formatJSON, false, "", "[export --format csv]"},
+		{"-f before export", []string{"-f", "json", "tickets"}, formatJSON, false, "", "[tickets]"},
+
+		// Everything after -- belongs to the command
+		{"after --", []string{"--offline", "tickets", "--", "-p", "-f"}, formatText, true, "", "[tickets -- -p -f]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, rest, err := parseGlobalFlags(tt.args)
+			if err != nil {
+				t.Fatalf("parseGlobalFlags(%q): %v", tt.args, err)
+			}
+			if o.format != tt.format || o.offline != tt.offline || o.profile != tt.profile {
+				t.Errorf("options = %+v, want format %s, offline %v, profile %q", o, tt.format, tt.offline, tt.profile)
+			}
+			if got := fmt.Sprint(rest); got != tt.rest {
+				t.Errorf("rest = %s, want %s", got, tt.rest)
+			}
+		})
+	}
+}
+
+func TestParseGlobalFlagsErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"tickets", "-p"},
+		{"stats", "--format"},
+		{"--format", "yaml", "stats"},
+	} {
+		if _, _, err := parseGlobalFlags(args); err == nil {
+			t.Errorf("parseGlobalFlags(%q): expected an error", args)
+		}
+	}
+}
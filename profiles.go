@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rursache/loto-cli/analytics"
+	"github.com/rursache/loto-cli/client"
+	"github.com/rursache/loto-cli/config"
+	"github.com/rursache/loto-cli/credentials"
+	"github.com/rursache/loto-cli/models"
+)
+
+// profileInfo describes a profile in "profiles list"
+type profileInfo struct {
+	Name    string `json:"name"`
+	Active  bool   `json:"active"`
+	Email   string `json:"email,omitempty"`
+	Backend string `json:"credential_backend,omitempty"` // empty if the profile has no config file yet
+	Dir     string `json:"dir"`
+}
+
+// profileStats are the statistics of one profile in "stats --all-profiles"
+type profileStats struct {
+	Profile string       `json:"profile"`
+	Email   string       `json:"email,omitempty"`
+	Stats   models.Stats `json:"stats"`
+}
+
+// allProfilesStats is the output of "stats --all-profiles"
+type allProfilesStats struct {
+	Profiles []profileStats `json:"profiles"`
+	Total    models.Stats   `json:"total"` // over the tickets of every profile
+	// Analytics are computed over the tickets of every profile
+	Analytics analytics.Report `json:"analytics"`
+	// Skipped lists the profiles whose tickets could not be loaded, left out of every total
+	Skipped []skippedProfile `json:"skipped,omitempty"`
+}
+
+// skippedProfile is a profile left out of "stats --all-profiles" and the reason why
+type skippedProfile struct {
+	Profile string `json:"profile"`
+	Error   string `json:"error"`
+}
+
+// runProfilesCmd is the CLI command handler for "profiles"
+func runProfilesCmd(args []string) {
+	if len(args) == 0 || args[0] == "list" {
+		runProfilesList()
+		return
+	}
+
+	fs := flag.NewFlagSet("profiles "+args[0], flag.ContinueOnError)
+	yes := fs.Bool("yes", false, "remove without asking for confirmation")
+	positional, err := parseInterspersed(fs, args[1:])
+	if err != nil {
+		os.Exit(1)
+	}
+	if len(positional) != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: loto-cli profiles %s <name>\n", args[0])
+		os.Exit(1)
+	}
+	name := positional[0]
+
+	switch args[0] {
+	case "add":
+		runProfilesAdd(name)
+	case "remove", "rm":
+		runProfilesRemove(name, *yes)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown profiles command: %s (expected list, add or remove)\n", args[0])
+		os.Exit(1)
+	}
+}
+
+func runProfilesList() {
+	names, err := config.ListProfiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing profiles: %v\n", err)
+		os.Exit(1)
+	}
+
+	active := config.ActiveProfile()
+	profiles := make([]profileInfo, 0, len(names))
+	for _, name := range names {
+		info := profileInfo{Name: name, Active: name == active}
+		info.Dir, _ = config.ProfileDir(name)
+		if cfg, ok := loadProfileConfig(name); ok {
+			info.Email, info.Backend = cfg.Email, cfg.Backend()
+		}
+		profiles = append(profiles, info)
+	}
+	config.UseProfile(active)
+
+	if structured() {
+		writeStructured("profiles", profiles)
+		return
+	}
+
+	for _, p := range profiles {
+		marker := " "
+		if p.Active {
+			marker = "*"
+		}
+		email := p.Email
+		switch {
+		case p.Backend == "":
+			email = "(no config)"
+		case email == "":
+			email = "(no email)"
+		}
+		fmt.Println(strings.TrimRight(fmt.Sprintf("%s %-16s %-32s %s", marker, p.Name, email, p.Backend), " "))
+	}
+}
+
+func runProfilesAdd(name string) {
+	if err := config.AddProfile(name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := config.UseProfile(name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := config.EnsureExists(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating config: %v\n", err)
+		os.Exit(1)
+	}
+
+	configPath, _ := config.GetConfigPath()
+	fmt.Printf("Profile %q created with config file %s\n", name, configPath)
+	fmt.Printf("Run \"loto-cli --profile %s login\" to set its credentials.\n", name)
+}
+
+func runProfilesRemove(name string, yes bool) {
+	if !yes {
+		if !isTerminal(os.Stdin) {
+			fmt.Fprintln(os.Stderr, "Error: pass --yes to remove a profile non-interactively")
+			os.Exit(1)
+		}
+		answer := prompt(fmt.Sprintf("Remove profile %q with its config, session and ticket archive? [y/N]", name), "")
+		if answer = strings.ToLower(answer); answer != "y" && answer != "yes" {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return
+		}
+	}
+
+	if err := config.RemoveProfile(name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, config.ErrProfileNotFound) {
+			os.Exit(exitConfig)
+		}
+		os.Exit(1)
+	}
+	fmt.Printf("Profile %q removed.\n", name)
+}
+
+// loadProfileConfig reads the config file of a profile, reporting false if it has none.
+// It leaves that profile selected.
+func loadProfileConfig(name string) (*config.Config, bool) {
+	if err := config.UseProfile(name); err != nil {
+		return nil, false
+	}
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return nil, false
+	}
+	if _, err := os.Stat(configPath); err != nil {
+		return nil, false
+	}
+	cfg, err := config.LoadOptional()
+	if err != nil {
+		return nil, false
+	}
+	return cfg, true
+}
+
+// runAllProfilesStats prints the statistics of every profile with a config file and
+// of their tickets combined, reading each archive with --offline or logging in to each account.
+// Profiles whose tickets cannot be loaded are skipped with a warning.
+func runAllProfilesStats(period analytics.Period, showBuckets bool) {
+	names, err := config.ListProfiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing profiles: %v\n", err)
+		os.Exit(1)
+	}
+
+	var out allProfilesStats
+	var all []models.Ticket
+	for _, name := range names {
+		cfg, ok := loadProfileConfig(name)
+		if !ok {
+			continue
+		}
+
+		tickets, err := profileTickets(name, cfg)
+		if errors.Is(err, context.Canceled) {
+			fatal("Interrupted", err)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping profile %s: %v\n", name, err)
+			out.Skipped = append(out.Skipped, skippedProfile{Profile: name, Error: err.Error()})
+			continue
+		}
+		all = append(all, tickets...)
+		out.Profiles = append(out.Profiles, profileStats{Profile: name, Email: cfg.Email, Stats: models.ComputeStats(tickets)})
+	}
+	out.Total = models.ComputeStats(all)
+	out.Analytics = analytics.Compute(all, period)
+
+	if structured() {
+		writeStructured("profile_stats", out)
+		return
+	}
+
+	if len(out.Profiles) == 0 && len(out.Skipped) == 0 {
+		fmt.Println("No profiles with a config file found.")
+		return
+	}
+
+	fmt.Printf("%-16s %-28s %8s %14s %14s %14s\n", "Profile", "Email", "Tickets", "Spent", "Won", "Net")
+	fmt.Println(strings.Repeat("-", 99))
+	for _, p := range out.Profiles {
+		fmt.Printf("%-16s %-28s %8d %10.2f RON %10.2f RON %+10.2f RON\n",
+			p.Profile, p.Email, p.Stats.TotalTickets, p.Stats.TotalSpent, p.Stats.TotalWon, p.Stats.NetResult)
+	}
+	for _, p := range out.Skipped {
+		fmt.Printf("%-16s skipped, not in the totals: %s\n", p.Profile, p.Error)
+	}
+	fmt.Println(strings.Repeat("-", 99))
+	fmt.Println()
+
+	if len(all) == 0 {
+		fmt.Println("No tickets found.")
+		return
+	}
+	fmt.Println("=== All Profiles ===")
+	fmt.Println()
+	printStats(out.Total)
+	printAnalytics(out.Analytics, showBuckets)
+}
+
+// profileTickets loads the tickets of the selected profile, from its archive with
+// --offline or by logging in to bilete.loto.ro with cfg
+func profileTickets(name string, cfg *config.Config) ([]models.Ticket, error) {
+	if opts.offline {
+		tickets, err := loadArchivedTickets()
+		if err != nil {
+			return nil, fmt.Errorf("reading the archive: %w", err)
+		}
+		return tickets, nil
+	}
+
+	if err := credentials.Resolve(cfg, promptPassphrase()); err != nil {
+		return nil, err
+	}
+	c, err := client.New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := commandContext(c)
+	defer cancel()
+
+	fmt.Fprintf(os.Stderr, "Profile %s: logging in to loto.ro...\n", name)
+	if err := c.Login(ctx); err != nil {
+		return nil, fmt.Errorf("login: %w", err)
+	}
+	tickets, err := c.GetAllTickets(ctx, stderrProgress())
+	if err := warnDetails(err); err != nil {
+		return nil, fmt.Errorf("fetching tickets: %w", err)
+	}
+	return tickets, nil
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rursache/loto-cli/client"
+)
+
+// progressLabels describe each stage of fetching the ticket history
+var progressLabels = map[string]string{
+	client.StagePages:   "Fetching ticket pages",
+	client.StageDetails: "Fetching ticket details",
+}
+
+// stderrProgress returns a progress reporter that redraws one status line per stage
+// on stderr, or nil when stderr is not a terminal
+func stderrProgress() client.ProgressFunc {
+	if !isTerminal(os.Stderr) {
+		return nil
+	}
+	return func(stage string, done, total int) {
+		fmt.Fprintf(os.Stderr, "\r%s... %d/%d", progressLabels[stage], done, total)
+		if done == total {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+}
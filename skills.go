@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/charmbracelet/x/term"
+
 	"github.com/rursache/loto-cli/config"
 )
 
@@ -21,6 +22,7 @@ const (
 var skillFiles = []string{
 	"SKILL.md",
 	"references/help-man-page.md",
+	"references/json-output.md",
 }
 
 // skillInstallDirs returns the target directories for skill installation
@@ -67,11 +69,7 @@ func markSkillPromptDone() {
 
 // isTerminal checks if a file descriptor is a terminal
 func isTerminal(f *os.File) bool {
-	info, err := f.Stat()
-	if err != nil {
-		return false
-	}
-	return (info.Mode() & os.ModeCharDevice) != 0
+	return term.IsTerminal(f.Fd())
 }
 
 // maybePromptSkillInstall prompts the user once to install AI skills
@@ -84,8 +82,7 @@ func maybePromptSkillInstall() {
 	}
 
 	fmt.Fprintf(os.Stderr, "Install AI skills for Claude Code and other agents? [y/N] ")
-	reader := bufio.NewReader(os.Stdin)
-	answer, _ := reader.ReadString('\n')
+	answer, _ := stdin.ReadString('\n')
 	answer = strings.TrimSpace(strings.ToLower(answer))
 
 	markSkillPromptDone()
@@ -0,0 +1,143 @@
+package store
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// isoDate is the layout draw dates are stored in, so they sort and compare as strings
+const isoDate = "2006-01-02"
+
+// displayDate is the layout archived draws are returned in (same as loto.ro)
+const displayDate = "02-01-2006"
+
+// DrawQuery selects archived draws. Zero values mean "no filter".
+type DrawQuery struct {
+	Game   models.Game
+	From   time.Time // inclusive
+	To     time.Time // inclusive
+	Limit  int       // 0 returns every matching draw
+	Offset int
+}
+
+// SaveDraws archives extractions, replacing any draw already stored for the same game, date and draw index.
+// Extractions without a parseable date are skipped. Returns the number of draws that were not archived before.
+func (s *Store) SaveDraws(exts []models.Extraction) (int, error) {
+	tx, err := s.draws.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().Unix()
+	added := 0
+
+	for _, ext := range exts {
+		date, err := models.ParseDrawDate(ext.Date)
+		if err != nil || len(ext.Numbers) == 0 {
+			continue
+		}
+		day := date.Format(isoDate)
+
+		var exists int
+		if err := tx.QueryRow(`SELECT COUNT(*) FROM draws WHERE game = ? AND draw_date = ? AND draw_index = ?`, string(ext.Game), day, ext.Draw).Scan(&exists); err != nil {
+			return 0, err
+		}
+		if exists == 0 {
+			added++
+		}
+
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO draws (game, draw_date, draw_index, numbers, bonus, updated_at) VALUES (?, ?, ?, ?, ?, ?)`,
+			string(ext.Game), day, ext.Draw, joinInts(ext.Numbers), joinInts(ext.Bonus), now,
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	return added, tx.Commit()
+}
+
+// Draws returns archived draws matching q, newest first (main draw before additional ones), and the total number of matches ignoring Limit/Offset
+func (s *Store) Draws(q DrawQuery) ([]models.Extraction, int, error) {
+	var where []string
+	var args []any
+	if q.Game != "" {
+		where = append(where, "game = ?")
+		args = append(args, string(q.Game))
+	}
+	if !q.From.IsZero() {
+		where = append(where, "draw_date >= ?")
+		args = append(args, q.From.Format(isoDate))
+	}
+	if !q.To.IsZero() {
+		where = append(where, "draw_date <= ?")
+		args = append(args, q.To.Format(isoDate))
+	}
+
+	clause := ""
+	if len(where) > 0 {
+		clause = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	if err := s.draws.QueryRow(`SELECT COUNT(*) FROM draws`+clause, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT game, draw_date, draw_index, numbers, bonus FROM draws` + clause + ` ORDER BY draw_date DESC, game, draw_index`
+	if q.Limit > 0 {
+		query += ` LIMIT ? OFFSET ?`
+		args = append(args, q.Limit, q.Offset)
+	}
+
+	rows, err := s.draws.Query(query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var draws []models.Extraction
+	for rows.Next() {
+		var game, day, numbers, bonus string
+		var draw int
+		if err := rows.Scan(&game, &day, &draw, &numbers, &bonus); err != nil {
+			return nil, 0, err
+		}
+		ext := models.Extraction{
+			Game:    models.Game(game),
+			Date:    day,
+			Draw:    draw,
+			Numbers: splitInts(numbers),
+			Bonus:   splitInts(bonus),
+		}
+		if t, err := time.Parse(isoDate, day); err == nil {
+			ext.Date = t.Format(displayDate)
+		}
+		draws = append(draws, ext)
+	}
+
+	return draws, total, rows.Err()
+}
+
+// joinInts encodes numbers as a space-separated string
+func joinInts(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, " ")
+}
+
+// splitInts decodes a space-separated string of numbers
+func splitInts(s string) []int {
+	var nums []int
+	for _, f := range strings.Fields(s) {
+		if n, err := strconv.Atoi(f); err == nil {
+			nums = append(nums, n)
+		}
+	}
+	return nums
+}
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// ReadDrawsFile reads historical draws from a CSV or JSON file, chosen by extension.
+//
+// CSV files have a header row and the columns game, date, numbers, bonus
+// (numbers and bonus are space-separated, bonus may be empty) and an optional
+// draw column numbering additional draws of the same date (0 or empty for the main draw):
+//
+//	game,date,numbers,bonus
+//	Loto 6/49,15.02.2026,1 23 33 48 2 35,
+//	Joker,15.02.2026,26 43 5 18 7,18
+//
+// JSON files contain either an array of extractions or the output of
+// "loto-cli results --format json".
+func ReadDrawsFile(path string) ([]models.Extraction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return readDrawsCSV(f)
+	case ".json":
+		return readDrawsJSON(f)
+	default:
+		return nil, fmt.Errorf("unsupported file type %q (expected .csv or .json)", filepath.Ext(path))
+	}
+}
+
+// readDrawsCSV parses draws from CSV with a game,date,numbers,bonus header
+func readDrawsCSV(r io.Reader) ([]models.Extraction, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"game", "date", "numbers"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column in CSV header", required)
+		}
+	}
+
+	field := func(rec []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(rec) {
+			return strings.TrimSpace(rec[i])
+		}
+		return ""
+	}
+
+	var draws []models.Extraction
+	for n, rec := range records[1:] {
+		draw := 0
+		if v := field(rec, "draw"); v != "" {
+			if draw, err = strconv.Atoi(v); err != nil || draw < 0 {
+				return nil, fmt.Errorf("line %d: invalid draw %q", n+2, v)
+			}
+		}
+		ext, err := newImportedDraw(field(rec, "game"), field(rec, "date"), draw, splitInts(field(rec, "numbers")), splitInts(field(rec, "bonus")))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+2, err)
+		}
+		draws = append(draws, ext)
+	}
+
+	return draws, nil
+}
+
+// readDrawsJSON parses draws from a JSON array or a results output envelope
+func readDrawsJSON(r io.Reader) ([]models.Extraction, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var exts []models.Extraction
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		var env struct {
+			Data []models.Extraction `json:"data"`
+		}
+		if err := json.Unmarshal(data, &env); err != nil {
+			return nil, err
+		}
+		exts = env.Data
+	} else if err := json.Unmarshal(data, &exts); err != nil {
+		return nil, err
+	}
+
+	draws := make([]models.Extraction, 0, len(exts))
+	for n, e := range exts {
+		ext, err := newImportedDraw(string(e.Game), e.Date, e.Draw, e.Numbers, e.Bonus)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", n+1, err)
+		}
+		draws = append(draws, ext)
+	}
+
+	return draws, nil
+}
+
+// newImportedDraw validates the fields of an imported draw
+func newImportedDraw(game, date string, draw int, numbers, bonus []int) (models.Extraction, error) {
+	g, err := models.ParseGame(game)
+	if err != nil {
+		return models.Extraction{}, err
+	}
+	if _, err := models.ParseDrawDate(date); err != nil {
+		return models.Extraction{}, err
+	}
+	if len(numbers) == 0 {
+		return models.Extraction{}, fmt.Errorf("no numbers")
+	}
+	return models.Extraction{Game: g, Date: date, Draw: draw, Numbers: numbers, Bonus: bonus}, nil
+}
@@ -0,0 +1,177 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	_ "modernc.org/sqlite" // registers the pure-Go "sqlite" driver
+
+	"github.com/rursache/loto-cli/config"
+)
+
+const archiveFileName = "archive.db"
+
+// Store is the local SQLite archive of ticket history and draw results
+type Store struct {
+	db    *sql.DB // ticket history of the profile
+	draws *sql.DB // draw results, shared by every profile; the same as db for the default one
+}
+
+// migrations are applied in order; the index+1 is the schema version stored in PRAGMA user_version.
+// Never edit an existing entry, only append new ones.
+var migrations = []string{
+	`CREATE TABLE tickets (
+		ticket_id  TEXT PRIMARY KEY,
+		seq        INTEGER NOT NULL,
+		order_id   TEXT NOT NULL,
+		game       TEXT NOT NULL,
+		price      TEXT NOT NULL,
+		draw_date  TEXT NOT NULL,
+		status     TEXT NOT NULL,
+		played_at  TEXT NOT NULL,
+		detail_url TEXT NOT NULL,
+		prize      TEXT NOT NULL,
+		updated_at INTEGER NOT NULL
+	);
+	CREATE INDEX tickets_seq ON tickets (seq);`,
+	`CREATE TABLE draws (
+		game       TEXT NOT NULL,
+		draw_date  TEXT NOT NULL,
+		numbers    TEXT NOT NULL,
+		bonus      TEXT NOT NULL,
+		updated_at INTEGER NOT NULL,
+		PRIMARY KEY (game, draw_date)
+	);`,
+	`ALTER TABLE tickets ADD COLUMN lines TEXT NOT NULL DEFAULT '';
+	ALTER TABLE tickets ADD COLUMN noroc TEXT NOT NULL DEFAULT '';
+	ALTER TABLE tickets ADD COLUMN detailed INTEGER NOT NULL DEFAULT 0;`,
+	// Additional draws share the game and date of the main draw
+	`CREATE TABLE draws_v2 (
+		game       TEXT NOT NULL,
+		draw_date  TEXT NOT NULL,
+		draw_index INTEGER NOT NULL DEFAULT 0,
+		numbers    TEXT NOT NULL,
+		bonus      TEXT NOT NULL,
+		updated_at INTEGER NOT NULL,
+		PRIMARY KEY (game, draw_date, draw_index)
+	);
+	INSERT INTO draws_v2 (game, draw_date, numbers, bonus, updated_at)
+		SELECT game, draw_date, numbers, bonus, updated_at FROM draws;
+	DROP TABLE draws;
+	ALTER TABLE draws_v2 RENAME TO draws;`,
+}
+
+// GetArchivePath returns the full path to the archive database of the active profile
+func GetArchivePath() (string, error) {
+	profileDir, err := config.GetProfileDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(profileDir, archiveFileName), nil
+}
+
+// GetDrawsPath returns the full path to the archive database holding the draw
+// results, which is the one in the config directory whatever the profile
+func GetDrawsPath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, archiveFileName), nil
+}
+
+// Open opens (creating if needed) the archive of the active profile and applies pending
+// migrations. Tickets are kept in the profile's archive, draw results in the shared one.
+func Open() (*Store, error) {
+	archivePath, err := GetArchivePath()
+	if err != nil {
+		return nil, err
+	}
+	drawsPath, err := GetDrawsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := OpenPath(archivePath)
+	if err != nil || drawsPath == archivePath {
+		return s, err
+	}
+	if s.draws, err = openDB(drawsPath); err != nil {
+		s.db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// OpenPath opens (creating if needed) the archive at the given path, holding both
+// tickets and draws, and applies pending migrations
+func OpenPath(path string) (*Store, error) {
+	db, err := openDB(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db, draws: db}, nil
+}
+
+// openDB opens (creating if needed) an archive database and applies pending migrations
+func openDB(path string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	// SQLite allows a single writer; one connection avoids "database is locked" errors
+	db.SetMaxOpenConns(1)
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate archive %s: %w", path, err)
+	}
+
+	return db, nil
+}
+
+// Close closes the underlying databases
+func (s *Store) Close() error {
+	err := s.db.Close()
+	if s.draws != s.db {
+		if drawsErr := s.draws.Close(); err == nil {
+			err = drawsErr
+		}
+	}
+	return err
+}
+
+// migrate brings the schema of db up to the latest version
+func migrate(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		// PRAGMA does not support placeholders
+		if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/rursache/loto-cli/config"
+	"github.com/rursache/loto-cli/models"
+)
+
+func TestOpenSharesDraws(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Cleanup(func() { config.UseProfile(config.DefaultProfile) })
+
+	if err := config.AddProfile("alice"); err != nil {
+		t.Fatal(err)
+	}
+	draw := models.Extraction{Game: models.GameLoto649, Date: "12-10-2026", Numbers: []int{1, 2, 3, 4, 5, 6}}
+
+	def, err := Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := def.SaveDraws([]models.Extraction{draw}); err != nil {
+		t.Fatal(err)
+	}
+	if err := def.save([]models.Ticket{ticket("1", models.StatusLost)}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	def.Close()
+
+	if err := config.UseProfile("alice"); err != nil {
+		t.Fatal(err)
+	}
+	alice, err := Open()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer alice.Close()
+
+	if _, total, err := alice.Draws(DrawQuery{}); err != nil || total != 1 {
+		t.Errorf("draws of profile alice = %d, %v, want the 1 draw of the default profile", total, err)
+	}
+	if ids := archivedIDs(t, alice); len(ids) != 0 {
+		t.Errorf("tickets of profile alice = %v, want none", ids)
+	}
+}
@@ -0,0 +1,282 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// TicketSource is the subset of the client used to sync the archive
+type TicketSource interface {
+	GetTickets(ctx context.Context, page int) ([]models.Ticket, int, error)
+	GetTicketDetails(ctx context.Context, detailURL string) (*models.TicketDetails, error)
+}
+
+// SyncResult summarizes what a sync changed
+type SyncResult struct {
+	Pages   int `json:"pages"`
+	Fetched int `json:"fetched"`
+	New     int `json:"new"`
+	Updated int `json:"updated"`
+	Details int `json:"details"`
+	Total   int `json:"total"`
+}
+
+// Tickets returns every archived ticket, newest first (same order as the site)
+func (s *Store) Tickets() ([]models.Ticket, error) {
+	rows, err := s.db.Query(`SELECT order_id, ticket_id, game, price, draw_date, status, played_at, detail_url, prize, lines, noroc
+		FROM tickets ORDER BY seq DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tickets []models.Ticket
+	for rows.Next() {
+		var t models.Ticket
+		var game, status, lines, noroc string
+		if err := rows.Scan(&t.OrderID, &t.TicketID, &game, &t.Price, &t.DrawDate, &status, &t.PlayedAt, &t.DetailURL, &t.Prize, &lines, &noroc); err != nil {
+			return nil, err
+		}
+		t.Game = models.Game(game)
+		t.Status = models.ParseTicketStatus(status)
+		if err := decodeJSON(lines, &t.Lines); err != nil {
+			return nil, fmt.Errorf("ticket %s: %w", t.TicketID, err)
+		}
+		if err := decodeJSON(noroc, &t.Noroc); err != nil {
+			return nil, fmt.Errorf("ticket %s: %w", t.TicketID, err)
+		}
+		t.Parse()
+		tickets = append(tickets, t)
+	}
+
+	return tickets, rows.Err()
+}
+
+// knownTicket is the archived state needed to decide whether a fetched ticket changed
+type knownTicket struct {
+	status    models.TicketStatus
+	prize     string
+	detailURL string
+	detailed  bool
+}
+
+// knownTickets returns the archived state of every ticket, keyed by TicketID
+func (s *Store) knownTickets() (map[string]knownTicket, error) {
+	rows, err := s.db.Query(`SELECT ticket_id, status, prize, detail_url, detailed FROM tickets`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	known := make(map[string]knownTicket)
+	for rows.Next() {
+		var id, status string
+		var k knownTicket
+		if err := rows.Scan(&id, &status, &k.prize, &k.detailURL, &k.detailed); err != nil {
+			return nil, err
+		}
+		k.status = models.ParseTicketStatus(status)
+		known[id] = k
+	}
+
+	return known, rows.Err()
+}
+
+// Sync fetches ticket history pages until it reaches tickets already in the archive,
+// inserting new tickets and updating archived ones whose status or prize changed.
+//
+// Paging stops once a page contains a known ticket and every archived pending ticket
+// has been seen again, so the status of older pending tickets is still refreshed.
+// It also stops after the last page: once the site's ticket count is reached or, when
+// the count is unknown, at an empty page or one with nothing new.
+// Detail pages (played lines, Noroc, prize) are fetched for new tickets, tickets
+// whose status changed, won tickets seen again, whose prize may have been corrected,
+// and archived tickets whose details were never fetched.
+func (s *Store) Sync(ctx context.Context, src TicketSource) (SyncResult, error) {
+	var res SyncResult
+
+	known, err := s.knownTickets()
+	if err != nil {
+		return res, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	pendingLeft := make(map[string]bool)
+	for id, k := range known {
+		if k.status == models.StatusPending {
+			pendingLeft[id] = true
+		}
+	}
+
+	var fetched []models.Ticket
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		tickets, total, err := src.GetTickets(ctx, page)
+		if err != nil {
+			return res, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+		res.Pages++
+
+		hitKnown, hitNew := false, false
+		for _, t := range tickets {
+			if _, ok := known[t.TicketID]; ok {
+				hitKnown = true
+				delete(pendingLeft, t.TicketID)
+			}
+			if !seen[t.TicketID] {
+				seen[t.TicketID] = true
+				hitNew = true
+				fetched = append(fetched, t)
+			}
+		}
+
+		// A total of 0 means the ticket count could not be read, so the last page is
+		// only recognized by being empty or repeating tickets already fetched
+		if !hitNew || (hitKnown && len(pendingLeft) == 0) || (total > 0 && len(fetched) >= total) {
+			break
+		}
+	}
+	res.Fetched = len(fetched)
+
+	var added, updated, rechecked []models.Ticket
+	changedIDs := make(map[string]bool)
+	for _, t := range fetched {
+		k, ok := known[t.TicketID]
+		switch {
+		case !ok:
+			added = append(added, t)
+		case k.status != t.Status || (t.Prize != "" && t.Prize != k.prize):
+			if t.Prize == "" {
+				t.Prize = k.prize
+			}
+			updated = append(updated, t)
+			changedIDs[t.TicketID] = true
+		case t.Status == models.StatusWon && k.detailed && t.DetailURL != "":
+			t.Prize = k.prize
+			rechecked = append(rechecked, t)
+		}
+	}
+
+	// Backfill details for archived tickets that were never detailed
+	for id, k := range known {
+		if !k.detailed && !changedIDs[id] && k.detailURL != "" {
+			updated = append(updated, models.Ticket{TicketID: id, Status: k.status, Prize: k.prize, DetailURL: k.detailURL})
+		}
+	}
+
+	detailed := make(map[string]bool)
+	for _, list := range [][]models.Ticket{added, updated} {
+		for i := range list {
+			if list[i].DetailURL == "" || ctx.Err() != nil {
+				continue
+			}
+			details, err := src.GetTicketDetails(ctx, list[i].DetailURL)
+			if err != nil {
+				continue // retried on the next sync
+			}
+			details.Apply(&list[i])
+			detailed[list[i].TicketID] = true
+			res.Details++
+		}
+	}
+
+	// Prizes are only shown on detail pages, so a corrected one is found by fetching them again
+	for _, t := range rechecked {
+		if ctx.Err() != nil {
+			break
+		}
+		details, err := src.GetTicketDetails(ctx, t.DetailURL)
+		if err != nil {
+			continue
+		}
+		res.Details++
+		if details.Prize == "" || details.Prize == t.Prize {
+			continue
+		}
+		details.Apply(&t)
+		updated = append(updated, t)
+		changedIDs[t.TicketID] = true
+		detailed[t.TicketID] = true
+	}
+	res.Updated = len(changedIDs)
+
+	if err := s.save(added, updated, detailed); err != nil {
+		return res, fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	res.New = len(added)
+	res.Total = len(known) + len(added)
+
+	// Tickets are saved even if fetching details was cut short; the rest are fetched next time
+	if err := ctx.Err(); err != nil {
+		return res, fmt.Errorf("fetching ticket details interrupted: %w", err)
+	}
+	return res, nil
+}
+
+// save inserts new tickets (given newest first) above everything archived and updates changed ones
+func (s *Store) save(added, updated []models.Ticket, detailed map[string]bool) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var maxSeq sql.NullInt64
+	if err := tx.QueryRow(`SELECT MAX(seq) FROM tickets`).Scan(&maxSeq); err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+
+	for i, t := range added {
+		seq := maxSeq.Int64 + int64(len(added)-i)
+		if _, err := tx.Exec(`INSERT INTO tickets
+			(ticket_id, seq, order_id, game, price, draw_date, status, played_at, detail_url, prize, lines, noroc, detailed, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			t.TicketID, seq, t.OrderID, string(t.Game), t.Price, t.DrawDate, t.Status.Key(), t.PlayedAt, t.DetailURL, t.Prize,
+			encodeJSON(t.Lines), encodeJSON(t.Noroc), detailed[t.TicketID], now,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range updated {
+		if detailed[t.TicketID] {
+			_, err = tx.Exec(`UPDATE tickets SET status = ?, prize = ?, lines = ?, noroc = ?, detailed = 1, updated_at = ? WHERE ticket_id = ?`,
+				t.Status.Key(), t.Prize, encodeJSON(t.Lines), encodeJSON(t.Noroc), now, t.TicketID)
+		} else {
+			_, err = tx.Exec(`UPDATE tickets SET status = ?, prize = ?, updated_at = ? WHERE ticket_id = ?`,
+				t.Status.Key(), t.Prize, now, t.TicketID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// encodeJSON serializes v for a TEXT column, using "" for empty slices
+func encodeJSON[T any](v []T) string {
+	if len(v) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// decodeJSON parses a TEXT column written by encodeJSON
+func decodeJSON(s string, v any) error {
+	if s == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(s), v)
+}
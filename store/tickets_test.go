@@ -0,0 +1,166 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// fakeSource serves ticket history pages and detail pages from memory
+type fakeSource struct {
+	pages   [][]models.Ticket
+	total   int // reported with every page; 0 when the count cannot be read
+	details map[string]*models.TicketDetails
+	fetched []int // pages requested, in order
+}
+
+func (f *fakeSource) GetTickets(ctx context.Context, page int) ([]models.Ticket, int, error) {
+	f.fetched = append(f.fetched, page)
+	if page > len(f.pages) {
+		return nil, f.total, nil
+	}
+	return f.pages[page-1], f.total, nil
+}
+
+func (f *fakeSource) GetTicketDetails(ctx context.Context, detailURL string) (*models.TicketDetails, error) {
+	d, ok := f.details[detailURL]
+	if !ok {
+		return nil, fmt.Errorf("no detail page %s", detailURL)
+	}
+	copied := *d
+	return &copied, nil
+}
+
+func ticket(id string, status models.TicketStatus) models.Ticket {
+	return models.Ticket{TicketID: id, OrderID: "O" + id, Game: models.GameLoto649, Status: status, DetailURL: "/bilet/" + id}
+}
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	s, err := OpenPath(filepath.Join(t.TempDir(), archiveFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func archivedIDs(t *testing.T, s *Store) []string {
+	t.Helper()
+	tickets, err := s.Tickets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, len(tickets))
+	for i, tk := range tickets {
+		ids[i] = tk.TicketID
+	}
+	return ids
+}
+
+func TestSyncUnknownTotal(t *testing.T) {
+	s := openTestStore(t)
+	src := &fakeSource{pages: [][]models.Ticket{
+		{ticket("5", models.StatusLost), ticket("4", models.StatusLost)},
+		{ticket("3", models.StatusLost), ticket("2", models.StatusLost)},
+		{ticket("1", models.StatusLost)},
+	}}
+
+	res, err := s.Sync(t.Context(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.New != 5 || res.Pages != 4 {
+		t.Errorf("Sync = %+v, want 5 new tickets from 4 pages (the last one empty)", res)
+	}
+	if got := fmt.Sprint(archivedIDs(t, s)); got != "[5 4 3 2 1]" {
+		t.Errorf("archive = %s, want [5 4 3 2 1]", got)
+	}
+
+	// A site that repeats its last page instead of returning an empty one
+	s = openTestStore(t)
+	src = &fakeSource{pages: [][]models.Ticket{
+		{ticket("2", models.StatusLost)},
+		{ticket("1", models.StatusLost)},
+		{ticket("1", models.StatusLost)},
+		{ticket("1", models.StatusLost)},
+	}}
+	if res, err := s.Sync(t.Context(), src); err != nil || res.New != 2 || res.Pages != 3 {
+		t.Errorf("Sync = %+v, %v, want 2 new tickets from 3 pages", res, err)
+	}
+}
+
+func TestSyncIncremental(t *testing.T) {
+	s := openTestStore(t)
+	src := &fakeSource{
+		total: 3,
+		pages: [][]models.Ticket{
+			{ticket("3", models.StatusPending), ticket("2", models.StatusLost)},
+			{ticket("1", models.StatusLost)},
+		},
+	}
+	if res, err := s.Sync(t.Context(), src); err != nil || res.New != 3 || res.Pages != 2 {
+		t.Fatalf("first Sync = %+v, %v, want 3 new tickets from 2 pages", res, err)
+	}
+
+	// A new ticket; the pending one was drawn and won
+	src = &fakeSource{
+		total: 4,
+		pages: [][]models.Ticket{
+			{ticket("4", models.StatusPending), ticket("3", models.StatusWon)},
+			{ticket("2", models.StatusLost), ticket("1", models.StatusLost)},
+		},
+		details: map[string]*models.TicketDetails{"/bilet/3": {Prize: "30,00 RON"}},
+	}
+	res, err := s.Sync(t.Context(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.New != 1 || res.Updated != 1 || res.Pages != 1 || res.Total != 4 {
+		t.Errorf("second Sync = %+v, want 1 new and 1 updated from 1 page, 4 in total", res)
+	}
+
+	tickets, err := s.Tickets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tickets[0].TicketID != "4" || tickets[1].Status != models.StatusWon || tickets[1].Prize != "30,00 RON" {
+		t.Errorf("archive = %+v", tickets[:2])
+	}
+}
+
+func TestSyncCorrectedPrize(t *testing.T) {
+	s := openTestStore(t)
+	src := &fakeSource{
+		total: 2,
+		pages: [][]models.Ticket{{ticket("2", models.StatusWon), ticket("1", models.StatusWon)}},
+		details: map[string]*models.TicketDetails{
+			"/bilet/2": {Prize: "30,00 RON"},
+			"/bilet/1": {Prize: "12,00 RON"},
+		},
+	}
+	if _, err := s.Sync(t.Context(), src); err != nil {
+		t.Fatal(err)
+	}
+
+	// The site corrects the prize of ticket 2; ticket 1 is unchanged
+	src.details["/bilet/2"] = &models.TicketDetails{Prize: "45,50 RON"}
+	res, err := s.Sync(t.Context(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.New != 0 || res.Updated != 1 {
+		t.Errorf("Sync = %+v, want 1 updated", res)
+	}
+
+	tickets, err := s.Tickets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tickets[0].Prize != "45,50 RON" || tickets[1].Prize != "12,00 RON" {
+		t.Errorf("prizes = %s, %s, want 45,50 RON and 12,00 RON", tickets[0].Prize, tickets[1].Prize)
+	}
+}
@@ -0,0 +1,202 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rursache/loto-cli/analytics"
+	"github.com/rursache/loto-cli/models"
+)
+
+// analysisGames are the games cycled with "g" on the Analysis tab, those with number draws
+var analysisGames = []models.Game{models.GameLoto649, models.GameLoto540, models.GameJoker}
+
+// analysisTop is the number of entries in the hot, cold, overdue, pair and triplet lists
+const analysisTop = 10
+
+// analysisBarWidth is the length of the longest histogram bar
+const analysisBarWidth = 30
+
+// analysisKeyHints are the extra footer hints shown on the Analysis tab
+var analysisKeyHints = []keyHint{
+	{"g", "game"},
+}
+
+// analysisState holds the Analysis tab's game and its analysis of the archived draws
+type analysisState struct {
+	gameIdx  int
+	gen      int // incremented per load, so the analysis of a previous game is dropped
+	analysis analytics.DrawAnalysis
+	err      error
+	loading  bool
+	loaded   bool
+}
+
+type analysisMsg struct {
+	gen      int
+	analysis analytics.DrawAnalysis
+	err      error
+}
+
+// handleAnalysisKey applies Analysis tab key bindings and returns a command to reanalyze if needed
+func (m *model) handleAnalysisKey(key string) tea.Cmd {
+	switch key {
+	case "g":
+		m.analysis.gameIdx = (m.analysis.gameIdx + 1) % len(analysisGames)
+		return m.loadAnalysis()
+	}
+	return nil
+}
+
+// loadAnalysis marks the Analysis tab as loading and returns the command analyzing the archived draws of its game
+func (m *model) loadAnalysis() tea.Cmd {
+	if m.src.Draws == nil {
+		return nil
+	}
+	// The spinner only keeps ticking while something is loading, so restart it if idle
+	idle := !m.loadingResults && !m.loadingTickets && !m.history.loading && !m.analysis.loading
+
+	m.analysis.loading = true
+	m.analysis.gen++
+	m.updateViewportContent()
+
+	load := m.src.Draws
+	gen := m.analysis.gen
+	game := analysisGames[m.analysis.gameIdx]
+	analyze := func() tea.Msg {
+		draws, _, err := load(game, 0, 0)
+		if err != nil {
+			return analysisMsg{gen: gen, err: err}
+		}
+		a, err := analytics.AnalyzeDraws(game, draws, 0, analysisTop)
+		return analysisMsg{gen: gen, analysis: a, err: err}
+	}
+
+	if !idle {
+		return analyze
+	}
+	return tea.Batch(m.spinner.Tick, analyze)
+}
+
+// renderAnalysisContent renders the Analysis tab: frequencies and distributions of archived draws
+func (m model) renderAnalysisContent() string {
+	s := m.analysis
+	if s.loading || !s.loaded {
+		return fmt.Sprintf("\n  %s Analyzing archived draws...", m.spinner.View())
+	}
+	if s.err != nil {
+		return errorStyle.Render(fmt.Sprintf("Error loading archive: %s", s.err))
+	}
+
+	a := s.analysis
+	title := lipgloss.NewStyle().Foreground(gameColor(string(a.Game))).Bold(true).Render(string(a.Game))
+	if a.Draws == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			gameSectionStyle.Render(title),
+			emptyStyle.Render("No archived draws to analyze. Results are archived each time they are fetched,\nor import older draws with \"loto-cli results import <file>\"."),
+		)
+	}
+
+	cardWidth := min(m.width-4, 90)
+	card := func(header string, rows ...string) string {
+		// The card's width includes its padding
+		h := statsSectionHeader.Copy().Width(cardWidth - 2).Render(header)
+		return statsCardStyle.Copy().Width(cardWidth).Render(
+			lipgloss.JoinVertical(lipgloss.Left, append([]string{h}, rows...)...),
+		)
+	}
+
+	sections := []string{
+		gameSectionStyle.Render(title + ticketIDStyle.Render(fmt.Sprintf("  %d draw(s), %s → %s", a.Draws, a.From, a.To))),
+		card("Hot & Cold",
+			statsRow("Hot", numberCounts(a.Hot)),
+			statsRow("Cold", numberCounts(a.Cold)),
+		),
+	}
+
+	var overdue []string
+	for _, n := range a.Overdue {
+		last := n.LastDrawn
+		if last == "" {
+			last = "never"
+		}
+		overdue = append(overdue, statsRow(fmt.Sprintf("%d", n.Number), fmt.Sprintf("%d draw(s) since, last %s", n.DrawsSince, last)))
+	}
+	sections = append(sections, card("Overdue", overdue...))
+
+	var combos []string
+	for _, c := range append(a.Pairs, a.Triplets...) {
+		combos = append(combos, statsRow(joinNumbers(c.Numbers, " "), fmt.Sprintf("%d times", c.Count)))
+	}
+	sections = append(sections, card("Drawn Together", combos...))
+
+	sections = append(sections,
+		card("Odd / Even", distributionRows(a.OddEven)...),
+		card(fmt.Sprintf("High / Low (high: %d+)", a.HighFrom), distributionRows(a.HighLow)...),
+	)
+
+	sums := []string{statsRow("Min / Max / Mean", fmt.Sprintf("%d / %d / %.1f", a.Sums.Min, a.Sums.Max, a.Sums.Mean))}
+	most := 0
+	for _, r := range a.Sums.Ranges {
+		most = max(most, r.Count)
+	}
+	for _, r := range a.Sums.Ranges {
+		sums = append(sums, histogramRow(fmt.Sprintf("%d-%d", r.From, r.To), r.Count, most, ""))
+	}
+	sections = append(sections, card("Sums", sums...))
+
+	sections = append(sections, card("Number Frequency", numberHistogram(a.Numbers)...))
+	if len(a.Joker) > 0 {
+		sections = append(sections, card("Joker Number Frequency", numberHistogram(a.Joker)...))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// numberCounts formats numbers with their counts, e.g. "7 (52)  23 (50)"
+func numberCounts(stats []analytics.NumberStat) string {
+	parts := make([]string, len(stats))
+	for i, n := range stats {
+		parts[i] = fmt.Sprintf("%d (%d)", n.Number, n.Count)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// numberHistogram renders a bar per number
+func numberHistogram(stats []analytics.NumberStat) []string {
+	most := 0
+	for _, n := range stats {
+		most = max(most, n.Count)
+	}
+	rows := make([]string, len(stats))
+	for i, n := range stats {
+		rows[i] = histogramRow(fmt.Sprintf("%d", n.Number), n.Count, most, fmt.Sprintf("%.1f%%", n.Percent))
+	}
+	return rows
+}
+
+// distributionRows renders a bar per distribution entry
+func distributionRows(dist []analytics.Distribution) []string {
+	most := 0
+	for _, d := range dist {
+		most = max(most, d.Count)
+	}
+	rows := make([]string, len(dist))
+	for i, d := range dist {
+		rows[i] = histogramRow(d.Label, d.Count, most, fmt.Sprintf("%.1f%%", d.Percent))
+	}
+	return rows
+}
+
+// histogramRow renders a labelled bar scaled to the largest count, followed by the count and a note
+func histogramRow(label string, count, most int, note string) string {
+	length := 0
+	if most > 0 {
+		length = count * analysisBarWidth / most
+	}
+	bar := lipgloss.NewStyle().Foreground(colorAccent).Render(strings.Repeat("█", length) + strings.Repeat(" ", analysisBarWidth-length))
+	return statsLabelStyle.Render(label) + "  " + bar + statsValueStyle.Render(fmt.Sprintf(" %4d  %s", count, note))
+}
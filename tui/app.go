@@ -1,15 +1,17 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
-	"github.com/rursache/loto-cli/client"
+	"github.com/rursache/loto-cli/analytics"
 	"github.com/rursache/loto-cli/models"
 )
 
@@ -20,70 +22,157 @@ const (
 	tabResults tab = iota
 	tabTickets
 	tabStats
+	tabHistory
+	tabAnalysis
 	tabCount // keep last for modular arithmetic
 )
 
-// Messages for async data fetching
+// Messages for async data fetching. gen is the load generation the fetch belongs
+// to, so results of fetches cancelled by a refresh are dropped.
 type resultsMsg struct {
+	gen     int
 	results []models.Extraction
 	err     error
 }
 
 type ticketsMsg struct {
+	gen     int
 	tickets []models.Ticket
 	err     error
 }
 
+// ticketsProgressMsg reports how far loading the tickets got. updates delivers the next one.
+type ticketsProgressMsg struct {
+	gen         int
+	stage       string // "pages" or "details"
+	done, total int
+	updates     chan ticketsProgressMsg
+}
+
+// Sources are the data loaders used by the TUI, so data can come from the
+// live site or the local archive. The context is cancelled when the user
+// quits or refreshes.
+type Sources struct {
+	Results func(context.Context) ([]models.Extraction, error)
+	// Jackpots returns the prize reports shown below the results; nil hides the section
+	Jackpots func(context.Context) ([]models.PrizeReport, error)
+	// Tickets reports its progress through the given function, which may be ignored.
+	// Tickets returned along with an error are shown, with the error as a warning.
+	Tickets func(context.Context, func(stage string, done, total int)) ([]models.Ticket, error)
+	// Draws returns one page of archived draws for a game (all games if empty) and the total count
+	Draws func(game models.Game, offset, limit int) ([]models.Extraction, int, error)
+	// Budget holds the spending limits; exceeding one shows a banner in the header
+	Budget analytics.Limits
+	// Timeout bounds each load started on launch or refresh; 0 means no limit
+	Timeout time.Duration
+}
+
 // model is the main Bubble Tea model
 type model struct {
-	client *client.Client
+	src Sources
+
+	// In-flight fetches of the current load generation are cancelled with cancel
+	parent   context.Context
+	fetchCtx context.Context
+	cancel   context.CancelFunc
+	gen      int
 
 	// UI state
-	activeTab    tab
-	width        int
-	height       int
-	ready        bool
-	viewport     viewport.Model
-	spinner      spinner.Model
+	activeTab tab
+	width     int
+	height    int
+	ready     bool
+	viewport  viewport.Model
+	spinner   spinner.Model
 
 	// Data
-	results       []models.Extraction
-	tickets       []models.Ticket
-	resultsErr    error
-	ticketsErr    error
+	results        []models.Extraction
+	tickets        []models.Ticket
+	resultsErr     error
+	ticketsErr     error
+	ticketsWarning error // tickets loaded, but only in part
 	loadingResults bool
 	loadingTickets bool
+	ticketsLoaded  ticketsProgressMsg       // latest progress while loadingTickets
+	overBudget     []analytics.BudgetStatus // exceeded limits, checked when tickets load
+	ticketsView    ticketsView              // Tickets tab filters
+	statsPeriodIdx int                      // Stats tab breakdown period, into analytics.Periods
+
+	// Jackpots section of the Results tab
+	jackpots        []models.PrizeReport
+	jackpotsErr     error
+	loadingJackpots bool
+
+	// History tab
+	history historyState
+
+	// Analysis tab
+	analysis analysisState
 }
 
-// Run starts the TUI application
-func Run(c *client.Client) error {
-	m := newModel(c)
-	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+// Run starts the TUI application. Fetches are cancelled when ctx is done.
+func Run(ctx context.Context, src Sources) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops fetches still running after quitting
+
+	m := newModel(ctx, src)
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithContext(ctx))
 	_, err := p.Run()
 	return err
 }
 
-func newModel(c *client.Client) model {
+func newModel(ctx context.Context, src Sources) model {
 	s := spinner.New(
 		spinner.WithSpinner(spinner.Dot),
 		spinner.WithStyle(spinnerStyle),
 	)
 
-	return model{
-		client:         c,
+	m := model{
+		src:            src,
+		parent:         ctx,
 		activeTab:      tabResults,
 		spinner:        s,
-		loadingResults: true,
-		loadingTickets: true,
+		statsPeriodIdx: statsPeriodIdx,
 	}
+	m.startLoad()
+	return m
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(
+	return m.fetchAll()
+}
+
+// startLoad cancels in-flight fetches and starts a new load generation
+func (m *model) startLoad() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+	if m.src.Timeout > 0 {
+		m.fetchCtx, m.cancel = context.WithTimeout(m.parent, m.src.Timeout)
+	} else {
+		m.fetchCtx, m.cancel = context.WithCancel(m.parent)
+	}
+	m.gen++
+
+	m.loadingResults = true
+	m.loadingTickets = true
+	m.ticketsLoaded = ticketsProgressMsg{}
+	m.loadingJackpots = m.src.Jackpots != nil
+	m.resultsErr, m.ticketsErr, m.jackpotsErr = nil, nil, nil
+	m.ticketsWarning = nil
+}
+
+// fetchAll returns the commands loading every tab of the current generation
+func (m model) fetchAll() tea.Cmd {
+	cmds := []tea.Cmd{
 		m.spinner.Tick,
-		fetchResults(m.client),
-		fetchTickets(m.client),
-	)
+		fetchResults(m.fetchCtx, m.gen, m.src.Results),
+		fetchTickets(m.fetchCtx, m.gen, m.src.Tickets),
+	}
+	if m.src.Jackpots != nil {
+		cmds = append(cmds, fetchJackpots(m.fetchCtx, m.gen, m.src.Jackpots))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -91,9 +180,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// While a ticket search is typed, keys are text rather than bindings or scrolling
+		if m.activeTab == tabTickets && m.ticketsView.searching && m.handleTicketsKey(msg) {
+			m.updateViewportContent()
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
+			m.cancel()
 			return m, tea.Quit
+		case "r":
+			m.startLoad()
+			m.updateViewportContent()
+			cmds = append(cmds, m.fetchAll())
 		case "tab", "right", "l":
 			m.activeTab = (m.activeTab + 1) % tabCount
 			m.updateViewportContent()
@@ -109,6 +209,34 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "3":
 			m.activeTab = tabStats
 			m.updateViewportContent()
+		case "4":
+			m.activeTab = tabHistory
+			m.updateViewportContent()
+		case "5":
+			m.activeTab = tabAnalysis
+			m.updateViewportContent()
+		default:
+			switch m.activeTab {
+			case tabHistory:
+				cmds = append(cmds, m.handleHistoryKey(msg.String()))
+			case tabAnalysis:
+				cmds = append(cmds, m.handleAnalysisKey(msg.String()))
+			case tabTickets:
+				if m.handleTicketsKey(msg) {
+					m.updateViewportContent()
+				}
+			case tabStats:
+				if m.handleStatsKey(msg.String()) {
+					m.updateViewportContent()
+				}
+			}
+		}
+
+		if m.activeTab == tabHistory && !m.history.loaded && !m.history.loading {
+			cmds = append(cmds, m.loadHistoryPage())
+		}
+		if m.activeTab == tabAnalysis && !m.analysis.loaded && !m.analysis.loading {
+			cmds = append(cmds, m.loadAnalysis())
 		}
 
 	case tea.WindowSizeMsg:
@@ -133,6 +261,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case resultsMsg:
+		if msg.gen != m.gen {
+			break
+		}
 		m.loadingResults = false
 		if msg.err != nil {
 			m.resultsErr = msg.err
@@ -141,17 +272,60 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.updateViewportContent()
 
+	case jackpotsMsg:
+		if msg.gen != m.gen {
+			break
+		}
+		m.loadingJackpots = false
+		m.jackpots = msg.reports
+		m.jackpotsErr = msg.err
+		m.updateViewportContent()
+
+	case ticketsProgressMsg:
+		if msg.gen != m.gen {
+			break
+		}
+		m.ticketsLoaded = msg
+		m.updateViewportContent()
+		cmds = append(cmds, waitTicketsProgress(msg.updates))
+
 	case ticketsMsg:
+		if msg.gen != m.gen {
+			break
+		}
 		m.loadingTickets = false
-		if msg.err != nil {
+		if msg.err != nil && msg.tickets == nil {
 			m.ticketsErr = msg.err
 		} else {
+			m.ticketsWarning = msg.err
 			m.tickets = msg.tickets
+			m.overBudget = analytics.Exceeded(analytics.CheckBudget(m.tickets, m.src.Budget, time.Now()))
 		}
 		m.updateViewportContent()
 
+	case drawsMsg:
+		if msg.gen != m.history.gen {
+			break
+		}
+		m.history.loading = false
+		m.history.loaded = true
+		m.history.err = msg.err
+		m.history.draws = msg.draws
+		m.history.total = msg.total
+		m.updateViewportContent()
+
+	case analysisMsg:
+		if msg.gen != m.analysis.gen {
+			break
+		}
+		m.analysis.loading = false
+		m.analysis.loaded = true
+		m.analysis.err = msg.err
+		m.analysis.analysis = msg.analysis
+		m.updateViewportContent()
+
 	case spinner.TickMsg:
-		if m.loadingResults || m.loadingTickets {
+		if m.loadingResults || m.loadingTickets || m.loadingJackpots || m.history.loading || m.analysis.loading {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			cmds = append(cmds, cmd)
@@ -188,9 +362,17 @@ func (m model) View() string {
 // renderHeader renders the top header bar
 func (m model) renderHeader() string {
 	title := appTitleStyle.Render(" loto-cli ")
-	line := strings.Repeat("─", max(0, m.width-lipgloss.Width(title)))
+	banner := ""
+	if len(m.overBudget) > 0 {
+		var over []string
+		for _, s := range m.overBudget {
+			over = append(over, fmt.Sprintf("%s %.2f of %.2f RON", s.Period.Adjective(), s.Spent.Float(), s.Limit.Float()))
+		}
+		banner = budgetBannerStyle.Render("⚠ Budget exceeded: " + strings.Join(over, ", "))
+	}
+	line := strings.Repeat("─", max(0, m.width-lipgloss.Width(title)-lipgloss.Width(banner)))
 	right := lipgloss.NewStyle().Foreground(colorBorder).Render(line)
-	return lipgloss.JoinHorizontal(lipgloss.Center, title, right)
+	return lipgloss.JoinHorizontal(lipgloss.Center, title, right, banner)
 }
 
 // renderTabBar renders the tab navigation
@@ -202,6 +384,8 @@ func (m model) renderTabBar() string {
 		{"Results", tabResults},
 		{"Tickets", tabTickets},
 		{"Stats", tabStats},
+		{"History", tabHistory},
+		{"Analysis", tabAnalysis},
 	}
 
 	var rendered []string
@@ -224,12 +408,28 @@ func (m model) renderTabBar() string {
 	return lipgloss.JoinHorizontal(lipgloss.Bottom, row, fillStyled)
 }
 
+// keyHint is a single key binding shown in the footer
+type keyHint struct{ key, desc string }
+
 // renderFooter renders the bottom keybinding help
 func (m model) renderFooter() string {
-	keys := []struct{ key, desc string }{
+	keys := []keyHint{
 		{"←/→/Tab", "switch tabs"},
 		{"↑/↓/j/k", "scroll"},
-		{"q", "quit"},
+	}
+	switch m.activeTab {
+	case tabTickets:
+		keys = append(keys, ticketsKeyHints...)
+	case tabStats:
+		keys = append(keys, statsKeyHints...)
+	case tabHistory:
+		keys = append(keys, historyKeyHints...)
+	case tabAnalysis:
+		keys = append(keys, analysisKeyHints...)
+	}
+	keys = append(keys, keyHint{"r", "refresh"}, keyHint{"q", "quit"})
+	if m.activeTab == tabTickets && m.ticketsView.searching {
+		keys = ticketsSearchKeyHints
 	}
 
 	var parts []string
@@ -258,6 +458,10 @@ func (m *model) updateViewportContent() {
 		content = m.renderTicketsContent()
 	case tabStats:
 		content = m.renderStatsContent()
+	case tabHistory:
+		content = m.renderHistoryContent()
+	case tabAnalysis:
+		content = m.renderAnalysisContent()
 	}
 
 	m.viewport.SetContent(content)
@@ -283,6 +487,9 @@ func (m model) renderResultsContent() string {
 		section := m.renderExtraction(ext)
 		sections = append(sections, section)
 	}
+	if jackpots := m.renderJackpotsContent(); jackpots != "" {
+		sections = append(sections, jackpots)
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
@@ -297,7 +504,7 @@ func (m model) renderExtraction(ext models.Extraction) string {
 	gameName := gameHeaderStyle.Copy().
 		Foreground(lipgloss.Color("#FFFFFF")).
 		Background(color).
-		Render(string(ext.Game))
+		Render(ext.Label())
 
 	date := gameDateStyle.Copy().
 		Background(color).
@@ -336,7 +543,7 @@ func (m model) renderExtraction(ext models.Extraction) string {
 // renderTicketsContent renders the ticket list for the viewport
 func (m model) renderTicketsContent() string {
 	if m.loadingTickets {
-		return fmt.Sprintf("\n  %s Loading tickets...", m.spinner.View())
+		return fmt.Sprintf("\n  %s Loading tickets...%s", m.spinner.View(), m.ticketsProgressText())
 	}
 
 	if m.ticketsErr != nil {
@@ -347,8 +554,19 @@ func (m model) renderTicketsContent() string {
 		return emptyStyle.Render("No tickets found.")
 	}
 
+	tickets := m.ticketsView.query().Apply(m.tickets)
+
 	var cards []string
-	for _, t := range m.tickets {
+	if m.ticketsWarning != nil {
+		cards = append(cards, warningStyle.Render("⚠ "+m.ticketsWarning.Error()))
+	}
+	if filter := m.ticketsFilterLine(len(tickets)); filter != "" {
+		cards = append(cards, " "+filter)
+	}
+	if len(tickets) == 0 {
+		cards = append(cards, emptyStyle.Render("No tickets match the filters (press c to clear them)."))
+	}
+	for _, t := range tickets {
 		card := m.renderTicket(t)
 		cards = append(cards, card)
 	}
@@ -390,6 +608,29 @@ func (m model) renderTicket(t models.Ticket) string {
 		rows = append(rows, prizeRow)
 	}
 
+	for i, l := range t.Lines {
+		label := ""
+		if i == 0 {
+			label = "Played:"
+		}
+		line := joinNumbers(l.Numbers, " ")
+		if l.Joker > 0 {
+			line += bonusLabelStyle.Render("+") + fmt.Sprintf("%d", l.Joker)
+		}
+		if l.Category != "" {
+			line += "  " + lipgloss.NewStyle().Foreground(colorStatusWon).Render("Cat. "+l.Category)
+		}
+		rows = append(rows, ticketLabelStyle.Render(label)+"  "+ticketDateStyle.Render(line))
+	}
+
+	for i, n := range t.Noroc {
+		label := ""
+		if i == 0 {
+			label = "Noroc:"
+		}
+		rows = append(rows, ticketLabelStyle.Render(label)+"  "+ticketDateStyle.Render(fmt.Sprintf("%s  (%s)", n.Number, n.Game)))
+	}
+
 	inner := lipgloss.JoinVertical(lipgloss.Left, rows...)
 
 	return ticketCardStyle.Copy().
@@ -414,7 +655,7 @@ func renderStatusBadge(s models.TicketStatus) string {
 // renderStatsContent renders the statistics tab
 func (m model) renderStatsContent() string {
 	if m.loadingTickets {
-		return fmt.Sprintf("\n  %s Loading ticket data...", m.spinner.View())
+		return fmt.Sprintf("\n  %s Loading ticket data...%s", m.spinner.View(), m.ticketsProgressText())
 	}
 
 	if m.ticketsErr != nil {
@@ -425,51 +666,7 @@ func (m model) renderStatsContent() string {
 		return emptyStyle.Render("No ticket data available for stats.")
 	}
 
-	tickets := m.tickets
-
-	// --- Compute stats ---
-	totalTickets := len(tickets)
-	var totalSpent, totalWon float64
-	var wonCount, lostCount, pendingCount int
-	gameCount := make(map[models.Game]int)
-	gameSpent := make(map[models.Game]float64)
-	gameWon := make(map[models.Game]int)
-	gameWonAmount := make(map[models.Game]float64)
-
-	for _, t := range tickets {
-		price := parsePrice(t.Price)
-		totalSpent += price
-		gameCount[t.Game]++
-		gameSpent[t.Game] += price
-
-		switch t.Status {
-		case models.StatusWon:
-			wonCount++
-			gameWon[t.Game]++
-			if t.Prize != "" {
-				prizeVal := parsePrice(t.Prize)
-				totalWon += prizeVal
-				gameWonAmount[t.Game] += prizeVal
-			}
-		case models.StatusLost:
-			lostCount++
-		case models.StatusPending:
-			pendingCount++
-		}
-	}
-
-	// Date range
-	firstDate := tickets[len(tickets)-1].DrawDate
-	lastDate := tickets[0].DrawDate
-
-	winRate := float64(0)
-	decided := wonCount + lostCount
-	if decided > 0 {
-		winRate = float64(wonCount) / float64(decided) * 100
-	}
-
-	avgPrice := totalSpent / float64(totalTickets)
-	netResult := totalWon - totalSpent
+	st := models.ComputeStats(m.tickets)
 
 	cardWidth := min(m.width-4, 60)
 
@@ -480,21 +677,21 @@ func (m model) renderStatsContent() string {
 	overviewHeader := statsSectionHeader.Copy().Width(cardWidth).Render("Overview")
 
 	// Format net result with color
-	netLabel := fmt.Sprintf("%.2f RON", netResult)
+	netLabel := fmt.Sprintf("%.2f RON", st.NetResult)
 	var netRendered string
-	if netResult >= 0 {
+	if st.NetResult >= 0 {
 		netRendered = lipgloss.NewStyle().Foreground(colorStatusWon).Bold(true).Render("+" + netLabel)
 	} else {
 		netRendered = lipgloss.NewStyle().Foreground(colorStatusLost).Bold(true).Render(netLabel)
 	}
 
 	overviewRows := []string{
-		statsRow("Total Tickets", fmt.Sprintf("%d", totalTickets)),
-		statsRow("Total Spent", fmt.Sprintf("%.2f RON", totalSpent)),
-		statsRow("Total Won", fmt.Sprintf("%.2f RON", totalWon)),
+		statsRow("Total Tickets", fmt.Sprintf("%d", st.TotalTickets)),
+		statsRow("Total Spent", fmt.Sprintf("%.2f RON", st.TotalSpent)),
+		statsRow("Total Won", fmt.Sprintf("%.2f RON", st.TotalWon)),
 		statsRow("Net Result", netRendered),
-		statsRow("Avg Ticket Price", fmt.Sprintf("%.2f RON", avgPrice)),
-		statsRow("Date Range", fmt.Sprintf("%s → %s", firstDate, lastDate)),
+		statsRow("Avg Ticket Price", fmt.Sprintf("%.2f RON", st.AvgTicketPrice)),
+		statsRow("Date Range", fmt.Sprintf("%s → %s", st.FirstDrawDate, st.LastDrawDate)),
 	}
 	overviewCard := statsCardStyle.Copy().Width(cardWidth).Render(
 		lipgloss.JoinVertical(lipgloss.Left, append([]string{overviewHeader}, overviewRows...)...),
@@ -504,33 +701,25 @@ func (m model) renderStatsContent() string {
 	// Win/Loss card
 	wlHeader := statsSectionHeader.Copy().Width(cardWidth).Render("Results")
 	wlRows := []string{
-		statsRow("Won", statusStyle(true, false, false).Render(fmt.Sprintf(" %d ", wonCount))),
-		statsRow("Lost", statusStyle(false, true, false).Render(fmt.Sprintf(" %d ", lostCount))),
+		statsRow("Won", statusStyle(true, false, false).Render(fmt.Sprintf(" %d ", st.Won))),
+		statsRow("Lost", statusStyle(false, true, false).Render(fmt.Sprintf(" %d ", st.Lost))),
 	}
-	if pendingCount > 0 {
-		wlRows = append(wlRows, statsRow("Pending", statusStyle(false, false, true).Render(fmt.Sprintf(" %d ", pendingCount))))
+	if st.Pending > 0 {
+		wlRows = append(wlRows, statsRow("Pending", statusStyle(false, false, true).Render(fmt.Sprintf(" %d ", st.Pending))))
 	}
-	wlRows = append(wlRows, statsRow("Win Rate", fmt.Sprintf("%.1f%%", winRate)))
+	wlRows = append(wlRows, statsRow("Win Rate", fmt.Sprintf("%.1f%%", st.WinRate)))
 	wlCard := statsCardStyle.Copy().Width(cardWidth).Render(
 		lipgloss.JoinVertical(lipgloss.Left, append([]string{wlHeader}, wlRows...)...),
 	)
 	sections = append(sections, wlCard)
 
 	// Per-game breakdown
-	games := []models.Game{models.GameLoto649, models.GameLoto540, models.GameJoker}
 	var gameRows []string
 	bgHeader := statsSectionHeader.Copy().Width(cardWidth).Render("By Game")
-	for _, g := range games {
-		count := gameCount[g]
-		if count == 0 {
-			continue
-		}
-		spent := gameSpent[g]
-		won := gameWon[g]
-		wonAmt := gameWonAmount[g]
-		color := gameColor(string(g))
-		name := lipgloss.NewStyle().Foreground(color).Bold(true).Render(string(g))
-		detail := fmt.Sprintf("%d tickets  •  %.2f RON spent  •  %d won (%.2f RON)", count, spent, won, wonAmt)
+	for _, gs := range st.ByGame {
+		color := gameColor(string(gs.Game))
+		name := lipgloss.NewStyle().Foreground(color).Bold(true).Render(string(gs.Game))
+		detail := fmt.Sprintf("%d tickets  •  %.2f RON spent  •  %d won (%.2f RON)", gs.Tickets, gs.Spent, gs.Won, gs.WonAmount)
 		gameRows = append(gameRows, name+"\n"+statsValueStyle.Render(detail))
 	}
 	if len(gameRows) > 0 {
@@ -540,6 +729,9 @@ func (m model) renderStatsContent() string {
 		sections = append(sections, bgCard)
 	}
 
+	report := analytics.Compute(m.tickets, analytics.Periods[m.statsPeriodIdx])
+	sections = append(sections, renderAnalyticsCards(report, cardWidth)...)
+
 	return lipgloss.JoinVertical(lipgloss.Left, sections...)
 }
 
@@ -548,32 +740,51 @@ func statsRow(label, value string) string {
 	return statsLabelStyle.Render(label) + "  " + statsValueStyle.Render(value)
 }
 
-// parsePrice extracts a float from "24,50 RON" format
-func parsePrice(s string) float64 {
-	s = strings.TrimSpace(s)
-	s = strings.TrimSuffix(s, " RON")
-	s = strings.TrimSuffix(s, "RON")
-	s = strings.TrimSpace(s)
-	s = strings.ReplaceAll(s, ",", ".")
-	var v float64
-	fmt.Sscanf(s, "%f", &v)
-	return v
-}
-
 // Async data fetching commands
 
-func fetchResults(c *client.Client) tea.Cmd {
+func fetchResults(ctx context.Context, gen int, load func(context.Context) ([]models.Extraction, error)) tea.Cmd {
 	return func() tea.Msg {
-		results, err := c.GetResults()
-		return resultsMsg{results: results, err: err}
+		results, err := load(ctx)
+		return resultsMsg{gen: gen, results: results, err: err}
 	}
 }
 
-func fetchTickets(c *client.Client) tea.Cmd {
+func fetchTickets(ctx context.Context, gen int, load func(context.Context, func(string, int, int)) ([]models.Ticket, error)) tea.Cmd {
+	updates := make(chan ticketsProgressMsg, 1)
+	progress := func(stage string, done, total int) {
+		// Keep only the latest update if the TUI falls behind
+		select {
+		case <-updates:
+		default:
+		}
+		updates <- ticketsProgressMsg{gen: gen, stage: stage, done: done, total: total, updates: updates}
+	}
+
+	run := func() tea.Msg {
+		defer close(updates)
+		tickets, err := load(ctx, progress)
+		return ticketsMsg{gen: gen, tickets: tickets, err: err}
+	}
+	return tea.Batch(run, waitTicketsProgress(updates))
+}
+
+// waitTicketsProgress waits for the next progress update of a tickets load
+func waitTicketsProgress(updates chan ticketsProgressMsg) tea.Cmd {
 	return func() tea.Msg {
-		tickets, err := c.GetAllTickets()
-		return ticketsMsg{tickets: tickets, err: err}
+		msg, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// ticketsProgressText describes the latest tickets loading progress, e.g. " pages 3/14"
+func (m model) ticketsProgressText() string {
+	if m.ticketsLoaded.total == 0 {
+		return ""
 	}
+	return fmt.Sprintf(" %s %d/%d", m.ticketsLoaded.stage, m.ticketsLoaded.done, m.ticketsLoaded.total)
 }
 
 // Helper functions
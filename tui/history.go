@@ -0,0 +1,152 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// historyPageSize is the number of archived draws shown per page
+const historyPageSize = 20
+
+// historyGames are the filters cycled with "g"; the empty game means all games
+var historyGames = append([]models.Game{""}, models.AllGames...)
+
+// historyKeyHints are the extra footer hints shown on the History tab
+var historyKeyHints = []keyHint{
+	{"g", "game"},
+	{"n/p", "page"},
+}
+
+// historyState holds the History tab's filter, paging and loaded page
+type historyState struct {
+	gameIdx int
+	page    int // zero-based
+	gen     int // incremented per load, so a slower earlier page is dropped
+	draws   []models.Extraction
+	total   int
+	err     error
+	loading bool
+	loaded  bool
+}
+
+type drawsMsg struct {
+	gen   int
+	draws []models.Extraction
+	total int
+	err   error
+}
+
+// handleHistoryKey applies History tab key bindings and returns a command to reload the page if needed
+func (m *model) handleHistoryKey(key string) tea.Cmd {
+	switch key {
+	case "g":
+		m.history.gameIdx = (m.history.gameIdx + 1) % len(historyGames)
+		m.history.page = 0
+	case "n", "]":
+		if (m.history.page+1)*historyPageSize >= m.history.total {
+			return nil
+		}
+		m.history.page++
+	case "p", "[":
+		if m.history.page == 0 {
+			return nil
+		}
+		m.history.page--
+	default:
+		return nil
+	}
+	return m.loadHistoryPage()
+}
+
+// loadHistoryPage marks the History tab as loading and returns the command fetching the current page
+func (m *model) loadHistoryPage() tea.Cmd {
+	if m.src.Draws == nil {
+		return nil
+	}
+	// The spinner only keeps ticking while something is loading, so restart it if idle
+	idle := !m.loadingResults && !m.loadingTickets && !m.history.loading && !m.analysis.loading
+
+	m.history.loading = true
+	m.history.gen++
+	m.updateViewportContent()
+
+	load := m.src.Draws
+	gen := m.history.gen
+	game := historyGames[m.history.gameIdx]
+	offset := m.history.page * historyPageSize
+	fetch := func() tea.Msg {
+		draws, total, err := load(game, offset, historyPageSize)
+		return drawsMsg{gen: gen, draws: draws, total: total, err: err}
+	}
+
+	if !idle {
+		return fetch
+	}
+	return tea.Batch(m.spinner.Tick, fetch)
+}
+
+// renderHistoryContent renders the History tab: a paged table of archived draws
+func (m model) renderHistoryContent() string {
+	h := m.history
+	game := historyGames[h.gameIdx]
+	filter := "All games"
+	if game != "" {
+		filter = string(game)
+	}
+
+	if h.loading || !h.loaded {
+		return fmt.Sprintf("\n  %s Loading archived draws...", m.spinner.View())
+	}
+
+	if h.err != nil {
+		return errorStyle.Render(fmt.Sprintf("Error loading archive: %s", h.err))
+	}
+
+	title := statsSectionHeader.Copy().Render("Archived draws — " + filter)
+
+	if h.total == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left,
+			gameSectionStyle.Render(title),
+			emptyStyle.Render("No archived draws. Results are archived each time they are fetched,\nor import older draws with \"loto-cli results import <file>\"."),
+		)
+	}
+
+	var rows []string
+	rows = append(rows, historyHeaderStyle.Render(fmt.Sprintf("%-12s %-20s %s", "Date", "Game", "Numbers")))
+	for _, ext := range h.draws {
+		numbers := joinNumbers(ext.Numbers, " ")
+		if ext.Game.IsDigitGame() {
+			numbers = joinNumbers(ext.Numbers, "")
+		}
+		if len(ext.Bonus) > 0 {
+			numbers += bonusLabelStyle.Render("+") + joinNumbers(ext.Bonus, " ")
+		}
+
+		gameName := lipgloss.NewStyle().Foreground(gameColor(string(ext.Game))).Bold(true).Render(fmt.Sprintf("%-20s", ext.Label()))
+		rows = append(rows, fmt.Sprintf("%-12s %s %s", ext.Date, gameName, numbers))
+	}
+
+	pages := (h.total + historyPageSize - 1) / historyPageSize
+	pager := ticketIDStyle.Render(fmt.Sprintf("Page %d/%d  •  %d draw(s)", h.page+1, pages, h.total))
+
+	return gameSectionStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		"",
+		pager,
+	))
+}
+
+// joinNumbers formats numbers joined by sep
+func joinNumbers(nums []int, sep string) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = fmt.Sprintf("%d", n)
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+type jackpotsMsg struct {
+	gen     int
+	reports []models.PrizeReport
+	err     error
+}
+
+// fetchJackpots loads the prize reports shown below the results
+func fetchJackpots(ctx context.Context, gen int, load func(context.Context) ([]models.PrizeReport, error)) tea.Cmd {
+	return func() tea.Msg {
+		reports, err := load(ctx)
+		return jackpotsMsg{gen: gen, reports: reports, err: err}
+	}
+}
+
+// renderJackpotsContent renders the jackpots and prize categories section of the Results tab
+func (m model) renderJackpotsContent() string {
+	title := statsSectionHeader.Copy().Render("Jackpots & Prizes")
+
+	switch {
+	case m.src.Jackpots == nil:
+		return ""
+	case m.loadingJackpots:
+		return gameSectionStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+			title,
+			fmt.Sprintf("%s Loading prize reports...", m.spinner.View()),
+		))
+	case m.jackpotsErr != nil:
+		return gameSectionStyle.Render(lipgloss.JoinVertical(lipgloss.Left,
+			title,
+			emptyStyle.Render(fmt.Sprintf("Prize reports unavailable: %s", m.jackpotsErr)),
+		))
+	case len(m.jackpots) == 0:
+		return ""
+	}
+
+	rows := []string{title}
+	for i, r := range m.jackpots {
+		if i > 0 {
+			rows = append(rows, "")
+		}
+
+		name := lipgloss.NewStyle().Foreground(gameColor(string(r.Game))).Bold(true).Render(string(r.Game))
+		if r.Date != "" {
+			name += ticketDateStyle.Render("  " + r.Date)
+		}
+		rows = append(rows, name)
+
+		if r.Jackpot != "" {
+			label := "Next jackpot"
+			if r.NextDraw != "" {
+				label += " (" + r.NextDraw + ")"
+			}
+			rows = append(rows, statsLabelStyle.Copy().Width(26).Render(label)+ticketPriceStyle.Render(r.Jackpot))
+		}
+
+		rows = append(rows, historyHeaderStyle.Render(fmt.Sprintf("%-6s %9s  %s", "Cat.", "Winners", "Prize")))
+		for _, p := range r.Categories {
+			prize := p.Prize
+			if prize == "" {
+				prize = "-"
+			}
+			if p.Rollover != "" {
+				prize += ticketIDStyle.Render("  report " + p.Rollover)
+			}
+			rows = append(rows, fmt.Sprintf("%-6s %9d  %s", p.Category, p.Winners, prize))
+		}
+	}
+
+	return gameSectionStyle.Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
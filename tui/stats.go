@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rursache/loto-cli/analytics"
+	"github.com/rursache/loto-cli/models"
+)
+
+// statsKeyHints are the extra footer hints shown on the Stats tab
+var statsKeyHints = []keyHint{
+	{"p", "period"},
+}
+
+// statsPeriodIdx is the default period of the Stats tab breakdown, an index into analytics.Periods
+const statsPeriodIdx = 1 // month
+
+// handleStatsKey applies Stats tab key bindings and reports whether the key was used
+func (m *model) handleStatsKey(key string) bool {
+	switch key {
+	case "p":
+		m.statsPeriodIdx = (m.statsPeriodIdx + 1) % len(analytics.Periods)
+		return true
+	}
+	return false
+}
+
+// renderAnalyticsCards renders the highlights and period breakdown cards of the Stats tab
+func renderAnalyticsCards(r analytics.Report, cardWidth int) []string {
+	hlHeader := statsSectionHeader.Copy().Width(cardWidth).Render("Highlights")
+	hlRows := []string{
+		statsRow("Return on Spend", fmt.Sprintf("%.1f%%", r.ReturnOnSpend)),
+		statsRow("Avg per Draw", fmt.Sprintf("%.2f RON (%d draws)", r.AvgSpendPerDraw.Float(), r.Draws)),
+	}
+	if r.BiggestWin != nil {
+		hlRows = append(hlRows, statsRow("Biggest Win", fmt.Sprintf("%.2f RON (%s, %s)", r.BiggestWin.Prize.Float(), r.BiggestWin.Game, r.BiggestWin.DrawDate)))
+	}
+	hlRows = append(hlRows, statsRow("Losing Streak", fmt.Sprintf("%d longest, %d current", r.LongestLosingStreak, r.CurrentLosingStreak)))
+	hlCard := statsCardStyle.Copy().Width(cardWidth).Render(
+		lipgloss.JoinVertical(lipgloss.Left, append([]string{hlHeader}, hlRows...)...),
+	)
+
+	name := string(r.Period)
+	bHeader := statsSectionHeader.Copy().Width(cardWidth).Render("By " + strings.ToUpper(name[:1]) + name[1:])
+	bRows := []string{lipgloss.NewStyle().Foreground(colorTextDim).Render(fmt.Sprintf("%-10s %7s %14s %14s", "Period", "Tickets", "Net (RON)", "Cumulative"))}
+	for _, b := range r.Buckets {
+		bRows = append(bRows, fmt.Sprintf("%s %s %s %s",
+			statsValueStyle.Render(fmt.Sprintf("%-10s", b.Key)),
+			statsValueStyle.Render(fmt.Sprintf("%7d", b.Tickets)),
+			netStyle(b.Net).Render(fmt.Sprintf("%+14.2f", b.Net.Float())),
+			netStyle(b.CumulativeNet).Render(fmt.Sprintf("%+14.2f", b.CumulativeNet.Float())),
+		))
+	}
+	bCard := statsCardStyle.Copy().Width(cardWidth).Render(
+		lipgloss.JoinVertical(lipgloss.Left, append([]string{bHeader}, bRows...)...),
+	)
+
+	return []string{hlCard, bCard}
+}
+
+// netStyle colors a net result as a win or a loss
+func netStyle(net models.Money) lipgloss.Style {
+	if net.Amount >= 0 {
+		return lipgloss.NewStyle().Foreground(colorStatusWon)
+	}
+	return lipgloss.NewStyle().Foreground(colorStatusLost)
+}
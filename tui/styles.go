@@ -20,11 +20,12 @@ var (
 	colorStatusUnknown = lipgloss.Color("#95A5A6") // gray
 
 	// Game header colors
-	colorLoto649   = lipgloss.Color("#E74C3C") // red
-	colorLoto540   = lipgloss.Color("#3498DB") // blue
-	colorJoker     = lipgloss.Color("#9B59B6") // purple
-	colorNoroc     = lipgloss.Color("#F39C12") // orange
+	colorLoto649    = lipgloss.Color("#E74C3C") // red
+	colorLoto540    = lipgloss.Color("#3498DB") // blue
+	colorJoker      = lipgloss.Color("#9B59B6") // purple
+	colorNoroc      = lipgloss.Color("#F39C12") // orange
 	colorSuperNoroc = lipgloss.Color("#1ABC9C") // teal
+	colorNorocPlus  = lipgloss.Color("#E91E63") // pink
 
 	// Number ball colors
 	colorBall      = lipgloss.Color("#2C3E50") // dark ball background
@@ -35,119 +36,125 @@ var (
 // Header styles
 var (
 	headerStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(colorText).
-		Background(colorPrimary).
-		Padding(0, 1).
-		Align(lipgloss.Center)
+			Bold(true).
+			Foreground(colorText).
+			Background(colorPrimary).
+			Padding(0, 1).
+			Align(lipgloss.Center)
 
 	appTitleStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(colorPrimary).
-		Padding(0, 1)
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(colorPrimary).
+			Padding(0, 1)
+
+	budgetBannerStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(colorStatusLost).
+				Padding(0, 1)
 )
 
 // Tab styles
 var (
 	activeTabStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(colorSecondary).
-		Padding(0, 2)
+			Bold(true).
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(colorSecondary).
+			Padding(0, 2)
 
 	inactiveTabStyle = lipgloss.NewStyle().
-		Foreground(colorTextDim).
-		Background(colorBorder).
-		Padding(0, 2)
+				Foreground(colorTextDim).
+				Background(colorBorder).
+				Padding(0, 2)
 
 	tabGapStyle = lipgloss.NewStyle().
-		Background(colorBorder).
-		Padding(0, 0)
+			Background(colorBorder).
+			Padding(0, 0)
 )
 
 // Footer style
 var (
 	footerStyle = lipgloss.NewStyle().
-		Foreground(colorTextDim).
-		Padding(0, 1)
+			Foreground(colorTextDim).
+			Padding(0, 1)
 
 	footerKeyStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(colorAccent)
+			Bold(true).
+			Foreground(colorAccent)
 
 	footerDescStyle = lipgloss.NewStyle().
-		Foreground(colorTextDim)
+			Foreground(colorTextDim)
 )
 
 // Game section styles
 var (
 	gameSectionStyle = lipgloss.NewStyle().
-		Padding(0, 1).
-		MarginBottom(1)
+				Padding(0, 1).
+				MarginBottom(1)
 
 	gameHeaderStyle = lipgloss.NewStyle().
-		Bold(true).
-		Padding(0, 1).
-		MarginBottom(0)
+			Bold(true).
+			Padding(0, 1).
+			MarginBottom(0)
 
 	gameDateStyle = lipgloss.NewStyle().
-		Foreground(colorTextDim).
-		Italic(true).
-		PaddingLeft(2)
+			Foreground(colorTextDim).
+			Italic(true).
+			PaddingLeft(2)
 
 	numbersRowStyle = lipgloss.NewStyle().
-		PaddingLeft(2).
-		PaddingTop(0)
+			PaddingLeft(2).
+			PaddingTop(0)
 
 	numberBallStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(colorBallText).
-		Background(colorBall).
-		Padding(0, 1).
-		MarginRight(1).
-		Align(lipgloss.Center)
+			Bold(true).
+			Foreground(colorBallText).
+			Background(colorBall).
+			Padding(0, 1).
+			MarginRight(1).
+			Align(lipgloss.Center)
 
 	bonusBallStyle = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(colorBallText).
-		Background(colorBonusBall).
-		Padding(0, 1).
-		MarginRight(1).
-		Align(lipgloss.Center)
+			Bold(true).
+			Foreground(colorBallText).
+			Background(colorBonusBall).
+			Padding(0, 1).
+			MarginRight(1).
+			Align(lipgloss.Center)
 
 	bonusLabelStyle = lipgloss.NewStyle().
-		Foreground(colorBonusBall).
-		Bold(true).
-		PaddingLeft(1).
-		MarginRight(1)
+			Foreground(colorBonusBall).
+			Bold(true).
+			PaddingLeft(1).
+			MarginRight(1)
 )
 
 // Ticket styles
 var (
 	ticketCardStyle = lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(colorBorder).
-		Padding(0, 1).
-		MarginBottom(1)
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(colorBorder).
+			Padding(0, 1).
+			MarginBottom(1)
 
 	ticketGameStyle = lipgloss.NewStyle().
-		Bold(true).
-		MarginRight(1)
+			Bold(true).
+			MarginRight(1)
 
 	ticketIDStyle = lipgloss.NewStyle().
-		Foreground(colorTextDim)
+			Foreground(colorTextDim)
 
 	ticketDateStyle = lipgloss.NewStyle().
-		Foreground(colorText)
+			Foreground(colorText)
 
 	ticketPriceStyle = lipgloss.NewStyle().
-		Foreground(colorAccent).
-		Bold(true)
+				Foreground(colorAccent).
+				Bold(true)
 
 	ticketLabelStyle = lipgloss.NewStyle().
-		Foreground(colorTextDim).
-		Width(10)
+				Foreground(colorTextDim).
+				Width(10)
 )
 
 // Status badge styles
@@ -168,11 +175,11 @@ func statusStyle(won bool, lost bool, pending bool) lipgloss.Style {
 // Loading/spinner style
 var (
 	spinnerStyle = lipgloss.NewStyle().
-		Foreground(colorPrimary)
+			Foreground(colorPrimary)
 
 	loadingTextStyle = lipgloss.NewStyle().
-		Foreground(colorTextDim).
-		PaddingLeft(1)
+				Foreground(colorTextDim).
+				PaddingLeft(1)
 )
 
 // Error style
@@ -181,6 +188,11 @@ var errorStyle = lipgloss.NewStyle().
 	Bold(true).
 	Padding(1, 2)
 
+// Warning style, for data that loaded only in part
+var warningStyle = lipgloss.NewStyle().
+	Foreground(colorStatusPending).
+	PaddingLeft(1)
+
 // Empty state style
 var emptyStyle = lipgloss.NewStyle().
 	Foreground(colorTextDim).
@@ -194,27 +206,32 @@ var separatorStyle = lipgloss.NewStyle().
 // Stats styles
 var (
 	statsCardStyle = lipgloss.NewStyle().
-		BorderStyle(lipgloss.RoundedBorder()).
-		BorderForeground(colorBorder).
-		Padding(0, 1).
-		MarginBottom(1)
+			BorderStyle(lipgloss.RoundedBorder()).
+			BorderForeground(colorBorder).
+			Padding(0, 1).
+			MarginBottom(1)
 
 	statsSectionHeader = lipgloss.NewStyle().
-		Bold(true).
-		Foreground(colorAccent).
-		MarginBottom(1).
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderBottom(true).
-		BorderForeground(colorBorder)
+				Bold(true).
+				Foreground(colorAccent).
+				MarginBottom(1).
+				BorderStyle(lipgloss.NormalBorder()).
+				BorderBottom(true).
+				BorderForeground(colorBorder)
 
 	statsLabelStyle = lipgloss.NewStyle().
-		Foreground(colorTextDim).
-		Width(18)
+			Foreground(colorTextDim).
+			Width(18)
 
 	statsValueStyle = lipgloss.NewStyle().
-		Foreground(colorText)
+			Foreground(colorText)
 )
 
+// History styles
+var historyHeaderStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(colorTextDim)
+
 // gameColor returns the appropriate color for a game type
 func gameColor(game string) lipgloss.Color {
 	switch game {
@@ -228,6 +245,8 @@ func gameColor(game string) lipgloss.Color {
 		return colorNoroc
 	case "Super Noroc":
 		return colorSuperNoroc
+	case "Noroc Plus":
+		return colorNorocPlus
 	default:
 		return colorPrimary
 	}
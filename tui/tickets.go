@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rursache/loto-cli/models"
+)
+
+// ticketStatuses are the status filters cycled with "s"; StatusUnknown means all statuses
+var ticketStatuses = []models.TicketStatus{models.StatusUnknown, models.StatusWon, models.StatusLost, models.StatusPending}
+
+// ticketSorts are the orders cycled with "o"; SortSite is the site order
+var ticketSorts = append([]models.TicketSort{models.SortSite}, models.TicketSorts...)
+
+// ticketsKeyHints are the extra footer hints shown on the Tickets tab
+var ticketsKeyHints = []keyHint{
+	{"g", "game"},
+	{"s", "status"},
+	{"o/O", "sort"},
+	{"/", "search"},
+	{"c", "clear"},
+}
+
+// ticketsSearchKeyHints replace every other footer hint while typing a search
+var ticketsSearchKeyHints = []keyHint{
+	{"Enter", "done"},
+	{"Esc", "cancel"},
+}
+
+// ticketsView holds the Tickets tab's filters, applied with the same query as "loto-cli tickets"
+type ticketsView struct {
+	gameIdx   int // into historyGames
+	statusIdx int
+	sortIdx   int
+	ascending bool
+	search    string
+	searching bool // typing into search
+}
+
+// query returns the ticket query for the current filters
+func (v ticketsView) query() models.TicketQuery {
+	return models.TicketQuery{
+		Game:      historyGames[v.gameIdx],
+		Status:    ticketStatuses[v.statusIdx],
+		Search:    v.search,
+		Sort:      ticketSorts[v.sortIdx],
+		Ascending: v.ascending,
+	}
+}
+
+// handleTicketsKey applies Tickets tab key bindings and reports whether the key was used
+func (m *model) handleTicketsKey(msg tea.KeyMsg) bool {
+	v := &m.ticketsView
+
+	if v.searching {
+		switch msg.Type {
+		case tea.KeyEnter:
+			v.searching = false
+		case tea.KeyEsc:
+			v.searching = false
+			v.search = ""
+		case tea.KeyBackspace:
+			if r := []rune(v.search); len(r) > 0 {
+				v.search = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			v.search += string(msg.Runes)
+		case tea.KeyCtrlC:
+			return false
+		}
+		return true
+	}
+
+	switch msg.String() {
+	case "g":
+		v.gameIdx = (v.gameIdx + 1) % len(historyGames)
+	case "s":
+		v.statusIdx = (v.statusIdx + 1) % len(ticketStatuses)
+	case "o":
+		v.sortIdx = (v.sortIdx + 1) % len(ticketSorts)
+	case "O":
+		v.ascending = !v.ascending
+	case "/":
+		v.searching = true
+	case "c":
+		*v = ticketsView{}
+	default:
+		return false
+	}
+	return true
+}
+
+// ticketsFilterLine describes the active filters above the ticket list, or returns
+// an empty string if there are none
+func (m model) ticketsFilterLine(shown int) string {
+	v := m.ticketsView
+	desc := v.query().String()
+	if v.searching {
+		return ticketLabelStyle.Render("Search:") + "  " + ticketIDStyle.Render(v.search+"█")
+	}
+	if desc == "" {
+		return ""
+	}
+	return ticketIDStyle.Render(fmt.Sprintf("Showing %d of %d ticket(s)  •  %s", shown, len(m.tickets), desc))
+}